@@ -0,0 +1,23 @@
+//go:build !release
+
+package golog
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// Assert logs at LPanic, followed by the current stack, when cond is false.
+// It only logs and does not exit. Building with the release tag compiles it
+// to a no-op.
+func Assert(cond bool, text string, args ...interface{}) {
+	if cond {
+		return
+	}
+
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LPanic, "assertion failed: "+text, args)
+	entry.render()
+	entry.Message += "\n" + strings.TrimRight(string(debug.Stack()), "\n")
+	logger.write(entry)
+}
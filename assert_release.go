@@ -0,0 +1,5 @@
+//go:build release
+
+package golog
+
+func Assert(cond bool, text string, args ...interface{}) {}
@@ -0,0 +1,30 @@
+//go:build !release
+
+package golog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestAssert(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Assert(true, "never logged")
+	golog.Assert(1+1 == 3, "math is %s", "broken")
+
+	if len(sink.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(sink.entries))
+	}
+	entry := sink.entries[0]
+	if entry.Level != golog.LPanic || !strings.HasPrefix(entry.Message, "assertion failed: math is broken\ngoroutine ") ||
+		!strings.Contains(entry.Message, "TestAssert") {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,160 @@
+package golog
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncOption configures asynchronous writing.
+type AsyncOption struct {
+	// QueueSize is the number of entries that can wait to be written;
+	// 1024 when 0.
+	QueueSize int
+
+	// DropWhenFull drops entries instead of waiting when the queue is
+	// full. The number dropped is logged once the queue has room again.
+	DropWhenFull bool
+}
+
+type asyncWriter struct {
+	gl           *GoLog
+	dropWhenFull bool
+	dropped      uint64
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan asyncItem
+	done   chan struct{}
+}
+
+type asyncItem struct {
+	gl       *GoLog
+	c        *config
+	entry    *Entry
+	minLevel Level
+	console  bool
+
+	// flushed, if set, marks a Flush and is closed when the worker gets
+	// to it.
+	flushed chan struct{}
+}
+
+// SetAsync hands entries to a background goroutine that formats and writes
+// them, so slow outputs and sinks no longer add latency to the calls that
+// log. Entries are rendered before they are queued, so arguments may be
+// reused once the call returns. Flush waits for the queue to drain; pass nil
+// to drain it and write synchronously again. Panic and Fatal drain it first.
+func (gl *GoLog) SetAsync(option *AsyncOption) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.async != nil {
+		gl.async.stop()
+		gl.async = nil
+	}
+
+	if option != nil {
+		gl.async = newAsyncWriter(gl, option)
+	}
+
+	gl.publish()
+}
+
+func newAsyncWriter(gl *GoLog, option *AsyncOption) *asyncWriter {
+	size := option.QueueSize
+	if size <= 0 {
+		size = 1024
+	}
+
+	a := &asyncWriter{
+		gl:           gl,
+		dropWhenFull: option.DropWhenFull,
+		queue:        make(chan asyncItem, size),
+		done:         make(chan struct{}),
+	}
+	go a.loop()
+
+	return a
+}
+
+func (a *asyncWriter) loop() {
+	defer close(a.done)
+
+	for item := range a.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+
+		watchFlush("async writer", 1, func() error {
+			item.gl.output(item.c, item.entry, item.minLevel, item.console)
+			return nil
+		})
+		if n := atomic.SwapUint64(&a.dropped, 0); n > 0 {
+			a.gl.writeInternal(LWarning, sprintf("async queue full: dropped %d entries", []interface{}{n}))
+		}
+	}
+}
+
+// enqueue queues a rendered entry for the worker and reports whether it
+// was taken care of; false means it must be written synchronously.
+func (a *asyncWriter) enqueue(gl *GoLog, c *config, entry *Entry, minLevel Level, console bool) bool {
+	if a == nil {
+		return false
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return false
+	}
+
+	item := asyncItem{gl: gl, c: c, entry: entry, minLevel: minLevel, console: console}
+	if !a.dropWhenFull {
+		a.queue <- item
+		return true
+	}
+
+	select {
+	case a.queue <- item:
+	default:
+		atomic.AddUint64(&a.dropped, 1)
+	}
+
+	return true
+}
+
+// flush waits until the entries queued so far are written.
+func (a *asyncWriter) flush() {
+	if a == nil {
+		return
+	}
+
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return
+	}
+	flushed := make(chan struct{})
+	queued := len(a.queue)
+	a.queue <- asyncItem{flushed: flushed}
+	a.mu.RUnlock()
+
+	watchFlush("async queue", queued, func() error {
+		<-flushed
+		return nil
+	})
+}
+
+// stop writes out the queue and ends the worker.
+func (a *asyncWriter) stop() {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+}
@@ -0,0 +1,80 @@
+package golog_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+// gateSink holds every write until its gate is closed.
+type gateSink struct {
+	memorySink
+	gate chan struct{}
+}
+
+func (s *gateSink) Write(entry *golog.Entry) error {
+	<-s.gate
+	return s.memorySink.Write(entry)
+}
+
+func TestAsync(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LWarning})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &gateSink{gate: make(chan struct{})}
+	golog.Std.AddSinkLevel(sink, golog.LInfo)
+	golog.Std.SetAsync(&golog.AsyncOption{})
+	defer golog.Std.SetAsync(nil)
+
+	args := []interface{}{"a"}
+	start := time.Now()
+	golog.Info("first %s", args...)
+	args[0] = "b"
+	golog.Info("second %s", args...)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("logging waited for the sink for %s", elapsed)
+	}
+
+	close(sink.gate)
+	golog.Std.Flush()
+
+	want := []string{"first a", "second b"}
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAsyncDropWhenFull(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LWarning})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &gateSink{gate: make(chan struct{})}
+	golog.Std.AddSinkLevel(sink, golog.LInfo)
+	golog.Std.SetAsync(&golog.AsyncOption{QueueSize: 1, DropWhenFull: true})
+
+	for i := 0; i < 10; i++ {
+		golog.Info("entry %d", i)
+	}
+
+	close(sink.gate)
+	golog.Std.Flush()
+	golog.Info("after")
+	golog.Std.SetAsync(nil)
+
+	written, dropped := 0, 0
+	for _, message := range sink.messages() {
+		var n int
+		if strings.HasPrefix(message, "entry ") {
+			written++
+		} else if _, err := fmt.Sscanf(message, "async queue full: dropped %d entries", &n); err == nil {
+			dropped += n
+		}
+	}
+	if written == 0 || dropped == 0 || written+dropped != 10 {
+		t.Errorf("expected 10 entries written or dropped, got %q", sink.messages())
+	}
+}
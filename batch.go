@@ -0,0 +1,154 @@
+package golog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// batchBacklog is how many full batches may wait while a flush is slow,
+// e.g. retrying an unreachable backend; entries beyond that are dropped.
+const batchBacklog = 10
+
+// batcher collects entries and hands them to flush in batches of at most
+// max, from a background goroutine, either when max entries are buffered or
+// every interval, whichever comes first. Flushes never run concurrently so
+// batches reach the backend in order.
+type batcher struct {
+	name  string
+	max   int
+	flush func([]Entry) error
+
+	mu      sync.Mutex
+	buf     []Entry
+	dropped int
+	flushMu sync.Mutex
+
+	// full wakes the loop when a batch is full.
+	full chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newBatcher(name string, max int, interval time.Duration, flush func([]Entry) error) *batcher {
+	b := &batcher{
+		name:  name,
+		max:   max,
+		flush: flush,
+		full:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.loop(interval)
+
+	return b
+}
+
+func (b *batcher) loop(interval time.Duration) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.full:
+		case <-b.done:
+			return
+		}
+
+		if err := b.Flush(); err != nil {
+			reportSinkError(err)
+		}
+	}
+}
+
+// add buffers entry for the loop without waiting for a flush, so a slow
+// backend does not hold up logging.
+func (b *batcher) add(entry *Entry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.buf) >= b.max*batchBacklog {
+		b.dropped++
+		return nil
+	}
+
+	b.buf = append(b.buf, *entry)
+	if len(b.buf) >= b.max {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Flush hands the entries buffered so far to flush, max at a time.
+func (b *batcher) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	pending, dropped := len(b.buf), b.dropped
+	b.dropped = 0
+	b.mu.Unlock()
+
+	if dropped > 0 {
+		reportSinkError(fmt.Errorf("golog: %s dropped %d entries while flushing fell behind", b.name, dropped))
+	}
+
+	var firstErr error
+	for pending > 0 {
+		n := pending
+		if n > b.max {
+			n = b.max
+		}
+		pending -= n
+
+		b.mu.Lock()
+		entries := b.buf[:n:n]
+		if b.buf = b.buf[n:]; len(b.buf) == 0 {
+			b.buf = nil
+		}
+		b.mu.Unlock()
+
+		err := watchFlush(b.name, len(entries), func() error {
+			return b.flush(entries)
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (b *batcher) close() error {
+	close(b.done)
+	b.wg.Wait()
+
+	return b.Flush()
+}
+
+// retryBackoff calls fn until it succeeds, reports a permanent failure, or
+// attempts are exhausted, doubling the wait between attempts.
+func retryBackoff(attempts int, wait time.Duration, fn func() (retry bool, err error)) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		var retry bool
+		if retry, err = fn(); err == nil || !retry {
+			return err
+		}
+
+		if i < attempts-1 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+	}
+
+	return err
+}
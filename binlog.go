@@ -0,0 +1,285 @@
+package golog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// Binary log format: the magic followed by records of
+//
+//	[kind][uvarint payload length][payload][crc32 of kind and payload]
+//
+// Dictionary records assign ids to caller and field key strings the first
+// time they appear; entry records refer to them by id and store the time as
+// a varint delta to the previous entry.
+const (
+	binlogMagic = "GLBIN002"
+
+	// binlogMagicV1 logs store levels numbered 1 to 9, before the levels
+	// were spaced apart for RegisterLevel.
+	binlogMagicV1 = "GLBIN001"
+
+	binlogDict  byte = 'D'
+	binlogEntry byte = 'E'
+)
+
+var ErrBinlogCorrupt = errors.New("golog: corrupt binary log record")
+
+type binlogState struct {
+	dict     []string
+	lastTime int64
+
+	// v1 numbers levels as GLBIN001 logs do.
+	v1 bool
+}
+
+type binlogEncoder struct {
+	binlogState
+	ids map[string]uint64
+}
+
+func newBinlogEncoder() *binlogEncoder {
+	return &binlogEncoder{ids: make(map[string]uint64)}
+}
+
+func (enc *binlogEncoder) add(s string) {
+	enc.ids[s] = uint64(len(enc.dict))
+	enc.dict = append(enc.dict, s)
+}
+
+// encode appends the records for entry, including any dictionary records it
+// needs, to buf.
+func (enc *binlogEncoder) encode(buf []byte, entry *Entry) []byte {
+	var payload []byte
+
+	id := func(s string) uint64 {
+		if id, ok := enc.ids[s]; ok {
+			return id
+		}
+
+		enc.add(s)
+		payload = binary.AppendUvarint(payload[:0], enc.ids[s])
+		payload = appendBinlogString(payload, s)
+		buf = appendBinlogRecord(buf, binlogDict, payload)
+
+		return enc.ids[s]
+	}
+
+	callerID := id(entry.Caller)
+	keyIDs := make([]uint64, len(entry.Fields))
+	for i, field := range entry.Fields {
+		keyIDs[i] = id(field.Key)
+	}
+
+	now := entry.Time.UnixNano()
+	payload = binary.AppendVarint(payload[:0], now-enc.lastTime)
+	enc.lastTime = now
+	if enc.v1 {
+		payload = append(payload, byte(entry.Level/levelStep))
+	} else {
+		payload = append(payload, byte(entry.Level))
+	}
+	payload = binary.AppendUvarint(payload, callerID)
+	payload = appendBinlogString(payload, entry.Message)
+	payload = binary.AppendUvarint(payload, uint64(len(entry.Fields)))
+	for i, field := range entry.Fields {
+		payload = binary.AppendUvarint(payload, keyIDs[i])
+		payload = appendBinlogString(payload, fmt.Sprint(field.Value))
+	}
+
+	return appendBinlogRecord(buf, binlogEntry, payload)
+}
+
+func appendBinlogString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendBinlogRecord(buf []byte, kind byte, payload []byte) []byte {
+	start := len(buf)
+	buf = append(buf, kind)
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	buf = append(buf, payload...)
+
+	crc := crc32.NewIEEE()
+	crc.Write(buf[start : start+1])
+	crc.Write(payload)
+
+	return binary.LittleEndian.AppendUint32(buf, crc.Sum32())
+}
+
+// BinaryReader iterates over the entries of a binary log.
+type BinaryReader struct {
+	binlogState
+	r      *bufio.Reader
+	offset int64
+}
+
+func NewBinaryReader(r io.Reader) (*BinaryReader, error) {
+	br := &BinaryReader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(binlogMagic))
+	if _, err := io.ReadFull(br.r, magic); err != nil {
+		return nil, err
+	}
+	switch string(magic) {
+	case binlogMagic:
+	case binlogMagicV1:
+		br.v1 = true
+	default:
+		return nil, errors.New("golog: not a binary log")
+	}
+	br.offset = int64(len(binlogMagic))
+
+	return br, nil
+}
+
+// Next returns the next entry, io.EOF at the end of the log, or
+// io.ErrUnexpectedEOF if the log ends in a partially written record.
+func (br *BinaryReader) Next() (*Entry, error) {
+	for {
+		kind, payload, n, err := br.readRecord()
+		if err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case binlogDict:
+			if err := br.define(payload); err != nil {
+				return nil, err
+			}
+			br.offset += n
+		case binlogEntry:
+			entry, err := br.decode(payload)
+			if err != nil {
+				return nil, err
+			}
+			br.offset += n
+			return entry, nil
+		default:
+			return nil, ErrBinlogCorrupt
+		}
+	}
+}
+
+// Offset is the position just after the last record successfully read.
+func (br *BinaryReader) Offset() int64 {
+	return br.offset
+}
+
+func (br *BinaryReader) readRecord() (byte, []byte, int64, error) {
+	kind, err := br.r.ReadByte()
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	size, err := binary.ReadUvarint(br.r)
+	if err != nil {
+		return 0, nil, 0, io.ErrUnexpectedEOF
+	}
+	if size > 64<<20 {
+		return 0, nil, 0, ErrBinlogCorrupt
+	}
+
+	payload := make([]byte, size+4)
+	if _, err := io.ReadFull(br.r, payload); err != nil {
+		return 0, nil, 0, io.ErrUnexpectedEOF
+	}
+	sum := binary.LittleEndian.Uint32(payload[size:])
+	payload = payload[:size]
+
+	crc := crc32.NewIEEE()
+	crc.Write([]byte{kind})
+	crc.Write(payload)
+	if crc.Sum32() != sum {
+		return 0, nil, 0, ErrBinlogCorrupt
+	}
+
+	n := 1 + int64(len(binary.AppendUvarint(nil, size))) + int64(size) + 4
+
+	return kind, payload, n, nil
+}
+
+func (br *BinaryReader) define(payload []byte) error {
+	id, n := binary.Uvarint(payload)
+	if n <= 0 || id != uint64(len(br.dict)) {
+		return ErrBinlogCorrupt
+	}
+
+	s, _, ok := readBinlogString(payload[n:])
+	if !ok {
+		return ErrBinlogCorrupt
+	}
+	br.dict = append(br.dict, s)
+
+	return nil
+}
+
+func (br *BinaryReader) lookup(payload []byte) (string, []byte, bool) {
+	id, n := binary.Uvarint(payload)
+	if n <= 0 || id >= uint64(len(br.dict)) {
+		return "", nil, false
+	}
+
+	return br.dict[id], payload[n:], true
+}
+
+func (br *BinaryReader) decode(payload []byte) (*Entry, error) {
+	delta, n := binary.Varint(payload)
+	if n <= 0 || len(payload) <= n {
+		return nil, ErrBinlogCorrupt
+	}
+	br.lastTime += delta
+
+	entry := &Entry{
+		Time:  time.Unix(0, br.lastTime),
+		Level: Level(payload[n]),
+	}
+	if br.v1 {
+		entry.Level *= levelStep
+	}
+	rest := payload[n+1:]
+
+	var ok bool
+	if entry.Caller, rest, ok = br.lookup(rest); !ok {
+		return nil, ErrBinlogCorrupt
+	}
+	if entry.Message, rest, ok = readBinlogString(rest); !ok {
+		return nil, ErrBinlogCorrupt
+	}
+
+	count, n := binary.Uvarint(rest)
+	if n <= 0 || count > uint64(len(rest)) {
+		return nil, ErrBinlogCorrupt
+	}
+	rest = rest[n:]
+
+	for i := uint64(0); i < count; i++ {
+		var field Field
+		var value string
+		if field.Key, rest, ok = br.lookup(rest); !ok {
+			return nil, ErrBinlogCorrupt
+		}
+		if value, rest, ok = readBinlogString(rest); !ok {
+			return nil, ErrBinlogCorrupt
+		}
+		field.Value = value
+		entry.Fields = append(entry.Fields, field)
+	}
+
+	return entry, nil
+}
+
+func readBinlogString(buf []byte) (string, []byte, bool) {
+	size, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < size {
+		return "", nil, false
+	}
+
+	return string(buf[n : n+int(size)]), buf[n+int(size):], true
+}
@@ -0,0 +1,45 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// BlobStore is the object storage backend of an ArchiveSink. Put must be
+// idempotent since failed uploads are retried with the same key.
+type BlobStore interface {
+	Put(key string, data []byte) error
+}
+
+func checkBlobResponse(resp *http.Response, key string) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("golog: upload of %s failed: %s: %s", key, resp.Status, body)
+}
+
+// escapeObjectKey percent-encodes everything but unreserved characters and
+// slashes, which is the strictest encoding object stores expect for keys.
+func escapeObjectKey(path string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+
+	return b.String()
+}
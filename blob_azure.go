@@ -0,0 +1,74 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type AzureBlobStoreOption struct {
+	Account   string
+	Container string
+
+	// SASToken is a shared access signature with create/write permission,
+	// with or without the leading "?".
+	SASToken string
+
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+type AzureBlobStore struct {
+	container string
+	sasToken  string
+	endpoint  string
+	client    *http.Client
+}
+
+func NewAzureBlobStore(option *AzureBlobStoreOption) (*AzureBlobStore, error) {
+	if option == nil || option.Container == "" {
+		return nil, errors.New("golog: azure blob store requires a container")
+	}
+	if option.Account == "" && option.Endpoint == "" {
+		return nil, errors.New("golog: azure blob store requires an account or endpoint")
+	}
+
+	s := &AzureBlobStore{
+		container: option.Container,
+		sasToken:  strings.TrimPrefix(option.SASToken, "?"),
+		endpoint:  strings.TrimRight(option.Endpoint, "/"),
+		client:    option.HTTPClient,
+	}
+	if s.endpoint == "" {
+		s.endpoint = "https://" + option.Account + ".blob.core.windows.net"
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	return s, nil
+}
+
+func (s *AzureBlobStore) Put(key string, data []byte) error {
+	u := s.endpoint + "/" + escapeObjectKey(s.container) + "/" + escapeObjectKey(key)
+	if s.sasToken != "" {
+		u += "?" + s.sasToken
+	}
+
+	req, err := http.NewRequest(http.MethodPut, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-version", "2020-10-02")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkBlobResponse(resp, key)
+}
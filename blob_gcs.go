@@ -0,0 +1,77 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const gcsEndpoint = "https://storage.googleapis.com"
+
+type GCSStoreOption struct {
+	Bucket string
+
+	// TokenSource returns an OAuth2 access token with storage write scope.
+	TokenSource func() (string, error)
+
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+type GCSStore struct {
+	bucket      string
+	tokenSource func() (string, error)
+	endpoint    string
+	client      *http.Client
+}
+
+func NewGCSStore(option *GCSStoreOption) (*GCSStore, error) {
+	if option == nil || option.Bucket == "" {
+		return nil, errors.New("golog: gcs store requires a bucket")
+	}
+	if option.TokenSource == nil {
+		return nil, errors.New("golog: gcs store requires a token source")
+	}
+
+	s := &GCSStore{
+		bucket:      option.Bucket,
+		tokenSource: option.TokenSource,
+		endpoint:    strings.TrimRight(option.Endpoint, "/"),
+		client:      option.HTTPClient,
+	}
+	if s.endpoint == "" {
+		s.endpoint = gcsEndpoint
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	return s, nil
+}
+
+func (s *GCSStore) Put(key string, data []byte) error {
+	token, err := s.tokenSource()
+	if err != nil {
+		return err
+	}
+
+	u := s.endpoint + "/upload/storage/v1/b/" + url.PathEscape(s.bucket) +
+		"/o?uploadType=media&name=" + url.QueryEscape(key)
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkBlobResponse(resp, key)
+}
@@ -0,0 +1,87 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type S3StoreOption struct {
+	Bucket      string
+	Region      string
+	Credentials AWSCredentials
+
+	// Endpoint switches to path-style requests against an S3-compatible
+	// service such as MinIO.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+type S3Store struct {
+	bucket      string
+	region      string
+	credentials AWSCredentials
+	endpoint    *url.URL
+	client      *http.Client
+}
+
+func NewS3Store(option *S3StoreOption) (*S3Store, error) {
+	if option == nil || option.Bucket == "" {
+		return nil, errors.New("golog: s3 store requires a bucket")
+	}
+
+	s := &S3Store{
+		bucket:      option.Bucket,
+		region:      awsRegion(option.Region),
+		credentials: option.Credentials.resolve(),
+		client:      option.HTTPClient,
+	}
+	if option.Endpoint != "" {
+		var err error
+		if s.endpoint, err = url.Parse(option.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	return s, nil
+}
+
+func (s *S3Store) objectURL(key string) *url.URL {
+	if s.endpoint != nil {
+		u := *s.endpoint
+		base := strings.TrimRight(u.Path, "/")
+		u.Path = base + "/" + s.bucket + "/" + key
+		u.RawPath = escapeObjectKey(base) + "/" + escapeObjectKey(s.bucket) + "/" + escapeObjectKey(key)
+		return &u
+	}
+
+	return &url.URL{
+		Scheme:  "https",
+		Host:    s.bucket + ".s3." + s.region + ".amazonaws.com",
+		Path:    "/" + key,
+		RawPath: "/" + escapeObjectKey(key),
+	}
+}
+
+func (s *S3Store) Put(key string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key).String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	signAWSv4(req, data, s.credentials, s.region, "s3", time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkBlobResponse(resp, key)
+}
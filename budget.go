@@ -0,0 +1,144 @@
+package golog
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const budgetSummaryLimit = 10
+
+// Budget caps how much a logger emits per Window. Once MaxEntries or
+// MaxBytes (of message text) is used up, entries are only counted per level
+// and fingerprint, and a summary of what was suppressed is logged when the
+// window ends. Zero limits are unlimited.
+type Budget struct {
+	Window     time.Duration
+	MaxEntries int
+	MaxBytes   int64
+}
+
+type budget struct {
+	Budget
+
+	mu         sync.Mutex
+	start      time.Time
+	entries    int
+	bytes      int64
+	suppressed map[budgetKey]*budgetOverflow
+	timer      *time.Timer
+}
+
+type budgetKey struct {
+	level       Level
+	fingerprint string
+}
+
+type budgetOverflow struct {
+	count   int
+	level   Level
+	caller  string
+	message string
+}
+
+// SetBudget enables a log budget; a zero Window disables it.
+func (gl *GoLog) SetBudget(b Budget) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.budget != nil {
+		gl.budget.mu.Lock()
+		if gl.budget.timer != nil {
+			gl.budget.timer.Stop()
+		}
+		gl.budget.mu.Unlock()
+	}
+
+	gl.budget = nil
+	if b.Window > 0 {
+		gl.budget = &budget{Budget: b}
+	}
+
+	gl.publish()
+}
+
+// spendBudget charges entry against the budget and reports whether it may
+// be written.
+func (gl *GoLog) spendBudget(b *budget, entry *Entry) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.start.IsZero() || !entry.Time.Before(b.start.Add(b.Window)) {
+		b.start = entry.Time
+		b.entries = 0
+		b.bytes = 0
+	}
+
+	size := int64(len(entry.Message))
+	if (b.MaxEntries <= 0 || b.entries < b.MaxEntries) &&
+		(b.MaxBytes <= 0 || b.bytes+size <= b.MaxBytes) {
+		b.entries++
+		b.bytes += size
+		return true
+	}
+
+	if b.suppressed == nil {
+		b.suppressed = make(map[budgetKey]*budgetOverflow)
+		b.timer = time.AfterFunc(b.start.Add(b.Window).Sub(gl.now()), func() {
+			gl.summarizeBudget(b)
+		})
+	}
+
+	key := budgetKey{level: entry.Level, fingerprint: fingerprint(entry)}
+	overflow, ok := b.suppressed[key]
+	if !ok {
+		overflow = &budgetOverflow{level: entry.Level, caller: entry.Caller, message: entry.Message}
+		b.suppressed[key] = overflow
+	}
+	overflow.count++
+
+	return false
+}
+
+// summarizeBudget logs what the budget suppressed during the last window,
+// largest groups first and then by message. Summaries bypass the budget.
+func (gl *GoLog) summarizeBudget(b *budget) {
+	b.mu.Lock()
+	suppressed := b.suppressed
+	b.suppressed = nil
+	b.timer = nil
+	window := b.Window
+	b.mu.Unlock()
+
+	overflows := make([]*budgetOverflow, 0, len(suppressed))
+	total := 0
+	for _, overflow := range suppressed {
+		overflows = append(overflows, overflow)
+		total += overflow.count
+	}
+	sort.Slice(overflows, func(i, j int) bool {
+		if overflows[i].count != overflows[j].count {
+			return overflows[i].count > overflows[j].count
+		}
+		return overflows[i].message < overflows[j].message
+	})
+
+	summary := func(text string, args ...interface{}) {
+		gl.writeInternal(LWarning, fmt.Sprintf(text, args...))
+	}
+
+	summary("log budget exceeded: suppressed %d entries in the last %s", total, window)
+	for i, overflow := range overflows {
+		if i == budgetSummaryLimit {
+			summary("log budget: ... and %d more kinds of entries", len(overflows)-i)
+			break
+		}
+		summary("log budget: suppressed %d %s entries like %q (%s)",
+			overflow.count, trimLevel(overflow.level), overflow.message, overflow.caller)
+	}
+}
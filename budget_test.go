@@ -0,0 +1,64 @@
+package golog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestBudget(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetBudget(golog.Budget{Window: 50 * time.Millisecond, MaxEntries: 2})
+	defer golog.Std.SetBudget(golog.Budget{})
+
+	for i := 0; i < 5; i++ {
+		golog.Info("request %d", i)
+	}
+	golog.Error("failure")
+	golog.Warn("alarm")
+
+	if n := len(sink.messages()); n != 2 {
+		t.Fatalf("expected 2 entries within budget, got %d", n)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	messages := sink.messages()
+	if len(messages) != 6 {
+		t.Fatalf("expected 4 summary entries, got %q", messages[2:])
+	}
+	if messages[2] != "log budget exceeded: suppressed 5 entries in the last 50ms" ||
+		!strings.HasPrefix(messages[3], `log budget: suppressed 3 info entries like "request 2"`) ||
+		!strings.HasPrefix(messages[4], `log budget: suppressed 1 warn entries like "alarm"`) ||
+		!strings.HasPrefix(messages[5], `log budget: suppressed 1 error entries like "failure"`) {
+		t.Errorf("unexpected summary %q", messages[2:])
+	}
+
+	golog.Info("next window")
+	if messages := sink.messages(); messages[len(messages)-1] != "next window" {
+		t.Errorf("budget did not reset: %q", messages)
+	}
+}
+
+func TestBudgetSinkOnly(t *testing.T) {
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	sink := &memorySink{}
+	gl.AddSinkLevel(sink, golog.LTrace)
+	gl.SetBudget(golog.Budget{Window: time.Hour, MaxEntries: 2})
+	defer gl.SetBudget(golog.Budget{})
+
+	// Entries below MinLevel only go to the sink but still spend the budget.
+	for i := 0; i < 3; i++ {
+		gl.Debug("detail %d", i)
+	}
+
+	if got := sink.messages(); len(got) != 2 {
+		t.Errorf("expected 2 entries within budget, got %q", got)
+	}
+}
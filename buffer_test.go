@@ -0,0 +1,41 @@
+package golog_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetBuffer(t *testing.T) {
+	if os.Getenv("GOLOG_BUFFER_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetBuffer(&golog.BufferOption{FlushInterval: time.Hour})
+		golog.Info("buffered")
+		fmt.Println("unbuffered")
+		golog.Warn("flushed")
+		golog.Info("pending")
+		golog.Std.Flush()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSetBuffer$")
+	cmd.Env = append(os.Environ(), "GOLOG_BUFFER_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	want := []string{"unbuffered", "buffered", "flushed", "pending"}
+	for i, suffix := range want {
+		if i >= len(lines) || !strings.HasSuffix(lines[i], suffix) {
+			t.Fatalf("expected lines ending in %q, got %q", want, lines)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type CallerFormat uint8
+
+const (
+	// CallerBase renders "handler.go:42".
+	CallerBase CallerFormat = iota
+
+	// CallerRelative renders "./pkg/server/handler.go:42" relative to the
+	// caller root, which IDE terminals turn into clickable links. Files
+	// outside the root keep their absolute path.
+	CallerRelative
+
+	// CallerPackage renders "server/handler.go:42", the file with its
+	// directory, which tells apart same-named files in different packages.
+	CallerPackage
+
+	// CallerFull renders the absolute "/src/app/pkg/server/handler.go:42".
+	CallerFull
+)
+
+func (gl *GoLog) SetCallerFormat(format CallerFormat) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.CallerFormat = format
+	gl.publish()
+}
+
+// SetCallerRoot sets the directory CallerRelative paths are relative to.
+// It defaults to the working directory.
+func (gl *GoLog) SetCallerRoot(root string) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.CallerRoot = root
+	gl.publish()
+}
+
+// SetCallerFunction appends the calling function to the caller, e.g.
+// "handler.go:42 server.Handler.ServeHTTP", with the package path and the
+// receiver's pointer marks removed.
+func (gl *GoLog) SetCallerFunction(enable bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.callerFunction = enable
+	gl.publish()
+}
+
+// SetCallerLookup turns the lookup of the call site on or off; it is on by
+// default. Without it entries skip runtime.Callers, about a microsecond
+// each, and show "unknown" as the caller, which suits high-volume loggers
+// such as access logs. Per-file levels and caller filters then no longer
+// match their entries.
+func (gl *GoLog) SetCallerLookup(enable bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.noCaller = !enable
+	gl.publish()
+}
+
+func (c *config) formatCaller(entry *Entry) {
+	if entry.File == "" {
+		return
+	}
+
+	path := filepath.ToSlash(entry.File)
+	switch c.callerFormat {
+	case CallerRelative:
+		if rel, ok := c.relativePath(entry.File); ok {
+			path = "./" + rel
+		}
+	case CallerPackage:
+		if i := strings.LastIndexByte(path, '/'); i >= 0 {
+			if j := strings.LastIndexByte(path[:i], '/'); j >= 0 {
+				path = path[j+1:]
+			}
+		}
+	case CallerFull:
+	default:
+		if !c.callerFunction {
+			return
+		}
+		path = filepath.Base(entry.File)
+	}
+
+	entry.Caller = fmt.Sprintf("%s:%d", path, entry.Line)
+	if c.callerFunction && entry.Function != "" {
+		entry.Caller += " " + shortFunction(entry.Function)
+	}
+}
+
+// shortFunction turns "example.com/app/server.(*Handler).ServeHTTP" into
+// "server.Handler.ServeHTTP".
+func shortFunction(function string) string {
+	if i := strings.LastIndexByte(function, '/'); i >= 0 {
+		function = function[i+1:]
+	}
+
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(function)
+}
+
+// relativePath returns file relative to the caller root with forward
+// slashes, or false if file lies outside the root.
+func (c *config) relativePath(file string) (string, bool) {
+	root := c.callerRoot
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+
+	rel, err := filepath.Rel(root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
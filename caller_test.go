@@ -0,0 +1,90 @@
+package golog_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestCallerRelative(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Std.SetCallerFormat(golog.CallerRelative)
+	golog.Info("cwd")
+
+	wd, _ := os.Getwd()
+	golog.Std.SetCallerRoot(filepath.Dir(wd))
+	golog.Info("parent")
+
+	golog.Std.SetCallerRoot(filepath.Join(wd, "elsewhere"))
+	golog.Info("outside")
+
+	callers := []string{sink.entries[0].Caller, sink.entries[1].Caller, sink.entries[2].Caller}
+	if !strings.HasPrefix(callers[0], "./caller_test.go:") {
+		t.Errorf("unexpected caller %q", callers[0])
+	}
+	if !strings.HasPrefix(callers[1], "./"+filepath.Base(wd)+"/caller_test.go:") {
+		t.Errorf("unexpected caller %q", callers[1])
+	}
+	if !strings.HasPrefix(callers[2], filepath.ToSlash(wd)+"/caller_test.go:") {
+		t.Errorf("unexpected caller %q", callers[2])
+	}
+}
+
+func TestCallerFormats(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	wd, _ := os.Getwd()
+	golog.Std.SetCallerFormat(golog.CallerPackage)
+	golog.Info("package")
+	golog.Std.SetCallerFormat(golog.CallerFull)
+	golog.Info("full")
+	golog.Std.SetCallerFormat(golog.CallerBase)
+	golog.Std.SetCallerFunction(true)
+	golog.Info("function")
+
+	want := []string{
+		filepath.Base(wd) + "/caller_test.go:",
+		filepath.ToSlash(wd) + "/caller_test.go:",
+		"caller_test.go:",
+	}
+	for i, entry := range sink.entries {
+		if !strings.HasPrefix(entry.Caller, want[i]) {
+			t.Errorf("%s: unexpected caller %q", entry.Message, entry.Caller)
+		}
+	}
+	if caller := sink.entries[2].Caller; !strings.HasSuffix(caller, " golog_test.TestCallerFormats") {
+		t.Errorf("expected the function in %q", caller)
+	}
+}
+
+func TestCallerLookup(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Std.SetCallerLookup(false)
+	golog.Info("without")
+	golog.Std.SetCallerLookup(true)
+	golog.Info("with")
+
+	if e := sink.entries[0]; e.Caller != "unknown" || e.File != "" {
+		t.Errorf("caller looked up: %+v", e)
+	}
+	if e := sink.entries[1]; !strings.HasPrefix(e.Caller, "caller_test.go:") {
+		t.Errorf("unexpected caller %q", e.Caller)
+	}
+}
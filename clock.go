@@ -0,0 +1,95 @@
+package golog
+
+import "time"
+
+// SetClock replaces time.Now as the source of entry timestamps and of the
+// logger's own time-based decisions (level profiles, budget windows), so
+// tests can pin dates or step across day boundaries. nil restores the wall
+// clock.
+func (gl *GoLog) SetClock(now func() time.Time) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.clock = now
+	gl.publish()
+}
+
+// SetTimeFormat sets the layout of the date in the text header, e.g.
+// time.RFC3339Nano for aggregators that need the zone offset. An empty
+// layout restores "2006-01-02 15:04:05". JSON and GCP lines always use
+// RFC 3339.
+func (gl *GoLog) SetTimeFormat(layout string) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.timeFormat = layout
+	gl.publish()
+}
+
+// SetUTC shows header dates in UTC rather than local time.
+func (gl *GoLog) SetUTC(utc bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.utc = utc
+	gl.publish()
+}
+
+// TimePrecision is the resolution of logged timestamps.
+type TimePrecision uint8
+
+const (
+	// TimeDefault shows seconds in the text header and as many fractional
+	// digits as needed, up to nanoseconds, in JSON and GCP lines.
+	TimeDefault TimePrecision = iota
+	TimeSeconds
+	TimeMillis
+	TimeMicros
+	TimeNanos
+)
+
+// fraction is the layout of the fractional seconds.
+func (precision TimePrecision) fraction() string {
+	switch precision {
+	case TimeMillis:
+		return ".000"
+	case TimeMicros:
+		return ".000000"
+	case TimeNanos:
+		return ".000000000"
+	}
+
+	return ""
+}
+
+func (precision TimePrecision) rfc3339() string {
+	if precision == TimeDefault {
+		return time.RFC3339Nano
+	}
+
+	return "2006-01-02T15:04:05" + precision.fraction() + "Z07:00"
+}
+
+// SetTimePrecision sets the fractional digits of timestamps in the default
+// text header and in JSON and GCP console lines. They are always written
+// in full, so lines sort by time even when many share a second. A layout
+// from SetTimeFormat is used as is.
+func (gl *GoLog) SetTimePrecision(precision TimePrecision) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.precision = precision
+	gl.publish()
+}
+
+func (gl *GoLog) now() time.Time {
+	return gl.config().now()
+}
+
+func (c *config) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+
+	return time.Now()
+}
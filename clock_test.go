@@ -0,0 +1,124 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestClock(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	now := time.Date(2018, 12, 31, 23, 59, 59, 0, time.UTC)
+	golog.Std.SetClock(func() time.Time { return now })
+
+	golog.Info("before midnight")
+	now = now.Add(time.Second)
+	golog.Info("after midnight")
+
+	golog.Std.SetClock(nil)
+	golog.Info("wall clock")
+
+	if len(sink.entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(sink.entries))
+	}
+	if !sink.entries[0].Time.Equal(time.Date(2018, 12, 31, 23, 59, 59, 0, time.UTC)) ||
+		!sink.entries[1].Time.Equal(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected times %v, %v", sink.entries[0].Time, sink.entries[1].Time)
+	}
+	if time.Since(sink.entries[2].Time) > time.Minute {
+		t.Errorf("expected wall clock time, got %v", sink.entries[2].Time)
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LInfo, &out)
+	zone := time.FixedZone("JST", 9*60*60)
+	golog.Std.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 5, 0, 0, zone) })
+
+	golog.Std.SetTimeFormat(time.RFC3339)
+	golog.Info("offset")
+	golog.Std.SetUTC(true)
+	golog.Info("utc")
+	golog.Std.SetTimeFormat("")
+	golog.Info("default layout")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	want := []string{"2018-07-01T09:05:00+09:00", "2018-07-01T00:05:00Z", "2018-07-01 00:05:00"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "[  info] "+want[i]+" (") {
+			t.Errorf("line %d: got %q, want date %s", i, line, want[i])
+		}
+	}
+}
+
+func TestTimeFormatOffset(t *testing.T) {
+	var out bytes.Buffer
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.SetLevelOutput(golog.LInfo, &out)
+	zone := time.FixedZone("JST", 9*60*60)
+	gl.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 5, 0, 0, zone) })
+	gl.SetTimeFormat(time.RFC3339)
+
+	// The offset is written as is whether or not the header template runs.
+	gl.Info("default header")
+	gl.SetPrefix("a&b")
+	gl.Info("with prefix")
+	gl.SetHeaderTemplate("{{.Date}} ")
+	gl.Info("custom header")
+
+	want := []string{
+		"[  info] 2018-07-01T09:05:00+09:00 (clock_test.go:",
+		"[  info] 2018-07-01T09:05:00+09:00 [a&b] (clock_test.go:",
+		"2018-07-01T09:05:00+09:00 custom header",
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]) {
+			t.Errorf("line %d: got %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestTimePrecision(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LInfo, &out)
+	golog.Std.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 5, 0, 120000000, time.UTC) })
+
+	golog.Std.SetTimePrecision(golog.TimeMillis)
+	golog.Info("text")
+	golog.Std.SetTimePrecision(golog.TimeMicros)
+	golog.Std.SetFormat(golog.FormatJSON)
+	golog.Info("json")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "[  info] 2018-07-01 09:05:00.120 (") {
+		t.Errorf("unexpected text line %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], `{"timestamp":"2018-07-01T09:05:00.120000Z",`) {
+		t.Errorf("unexpected json line %q", lines[1])
+	}
+}
@@ -0,0 +1,222 @@
+// Command gologd relays entries that local processes send with
+// golog.RelaySink to one set of sinks, giving multi-process deployments a
+// single shipping point. Entries from all connections are merged in
+// arrival order and stamped with "source" and "received" fields.
+//
+//	gologd -listen unix:///run/gologd.sock,tcp://127.0.0.1:7514 -file /var/log/app.log
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+type relay struct {
+	sinks   []golog.Sink
+	entries chan *golog.Entry
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	seq   int
+	wg    sync.WaitGroup
+}
+
+func newRelay(sinks []golog.Sink) *relay {
+	return &relay{
+		sinks:   sinks,
+		entries: make(chan *golog.Entry, 1024),
+		conns:   make(map[net.Conn]struct{}),
+	}
+}
+
+// serve accepts connections on ln until it is closed.
+func (r *relay) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("gologd: accept: %v", err)
+			}
+			return
+		}
+
+		r.mu.Lock()
+		r.conns[conn] = struct{}{}
+		r.seq++
+		source := conn.RemoteAddr().String()
+		if source == "" || source == "@" {
+			source = fmt.Sprintf("%s#%d", conn.LocalAddr().Network(), r.seq)
+		}
+		r.mu.Unlock()
+
+		r.wg.Add(1)
+		go r.read(conn, source)
+	}
+}
+
+func (r *relay) read(conn net.Conn, source string) {
+	defer r.wg.Done()
+	defer func() {
+		r.mu.Lock()
+		delete(r.conns, conn)
+		r.mu.Unlock()
+		conn.Close()
+	}()
+
+	br, err := golog.NewBinaryReader(conn)
+	if err != nil {
+		log.Printf("gologd: %s: %v", source, err)
+		return
+	}
+
+	for {
+		entry, err := br.Next()
+		if err != nil {
+			return
+		}
+
+		entry.Fields = append(entry.Fields,
+			golog.Field{Key: "source", Value: source},
+			golog.Field{Key: "received", Value: time.Now().Format(time.RFC3339Nano)})
+		r.entries <- entry
+	}
+}
+
+// run writes entries to every sink until the entries channel is closed.
+func (r *relay) run() {
+	for entry := range r.entries {
+		for _, sink := range r.sinks {
+			if err := sink.Write(entry); err != nil {
+				log.Printf("gologd: sink write failed: %v", err)
+			}
+		}
+	}
+}
+
+// shutdown drops the open connections and stops run once their readers
+// are done.
+func (r *relay) shutdown() {
+	r.mu.Lock()
+	for conn := range r.conns {
+		conn.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	close(r.entries)
+}
+
+// stdoutSink prints entries as text lines.
+type stdoutSink struct{}
+
+func (stdoutSink) Write(entry *golog.Entry) error {
+	_, err := fmt.Printf("[%s] %s (%s): %s\n",
+		entry.Level, entry.Time.Format("2006-01-02 15:04:05"), entry.Caller, entry.Message)
+	return err
+}
+
+func (stdoutSink) Close() error {
+	return nil
+}
+
+func listen(spec string) (net.Listener, error) {
+	network, address, ok := strings.Cut(spec, "://")
+	if !ok {
+		return nil, fmt.Errorf("listen address %q is not of the form network://address", spec)
+	}
+
+	if network == "unix" {
+		os.Remove(address)
+	}
+
+	return net.Listen(network, address)
+}
+
+func main() {
+	listenFlag := flag.String("listen", "unix:///tmp/gologd.sock", "comma separated unix://path and tcp://host:port addresses")
+	fileFlag := flag.String("file", "", "write entries as text to this file")
+	maxSizeFlag := flag.Int64("max-size", 0, "rotate -file once it exceeds this many bytes")
+	binaryFlag := flag.String("binary", "", "write entries to this file in the binary log format")
+	syslogFlag := flag.String("syslog", "", `forward entries to syslog: "local" or network://host:port`)
+	stdoutFlag := flag.Bool("stdout", false, "print entries to stdout")
+	flag.Parse()
+
+	var sinks []golog.Sink
+	if *fileFlag != "" {
+		sink, err := golog.NewFileSink(&golog.FileSinkOption{Path: *fileFlag, MaxSize: *maxSizeFlag})
+		if err != nil {
+			log.Fatalf("gologd: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if *binaryFlag != "" {
+		sink, err := golog.NewBinarySink(&golog.BinarySinkOption{Path: *binaryFlag})
+		if err != nil {
+			log.Fatalf("gologd: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if *syslogFlag != "" {
+		option := &golog.SyslogSinkOption{Tag: "gologd"}
+		if *syslogFlag != "local" {
+			network, address, ok := strings.Cut(*syslogFlag, "://")
+			if !ok {
+				log.Fatalf("gologd: syslog address %q is not of the form network://address", *syslogFlag)
+			}
+			option.Network, option.Address = network, address
+		}
+		sink, err := golog.NewSyslogSink(option)
+		if err != nil {
+			log.Fatalf("gologd: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if *stdoutFlag || len(sinks) == 0 {
+		sinks = append(sinks, stdoutSink{})
+	}
+
+	r := newRelay(sinks)
+
+	var listeners []net.Listener
+	for _, spec := range strings.Split(*listenFlag, ",") {
+		ln, err := listen(strings.TrimSpace(spec))
+		if err != nil {
+			log.Fatalf("gologd: %v", err)
+		}
+		listeners = append(listeners, ln)
+		go r.serve(ln)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.run()
+		close(done)
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+
+	for _, ln := range listeners {
+		ln.Close()
+	}
+	r.shutdown()
+	<-done
+
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			log.Printf("gologd: %v", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []golog.Entry
+}
+
+func (s *memorySink) Write(entry *golog.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, *entry)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gologd.sock")
+	ln, err := listen("unix://" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &memorySink{}
+	r := newRelay([]golog.Sink{sink})
+	go r.serve(ln)
+
+	done := make(chan struct{})
+	go func() {
+		r.run()
+		close(done)
+	}()
+
+	for i, message := range []string{"from a", "from b"} {
+		client, err := golog.NewRelaySink(&golog.RelaySinkOption{Network: "unix", Address: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "main.go:1", Message: message})
+		defer client.Close()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			sink.mu.Lock()
+			n := len(sink.entries)
+			sink.mu.Unlock()
+			if n > i || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	ln.Close()
+	r.shutdown()
+	<-done
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+	sources := map[interface{}]bool{}
+	for i, entry := range sink.entries {
+		if len(entry.Fields) != 2 || entry.Fields[0].Key != "source" || entry.Fields[1].Key != "received" {
+			t.Fatalf("entry %d: unexpected fields %+v", i, entry.Fields)
+		}
+		sources[entry.Fields[0].Value] = true
+	}
+	if len(sources) != 2 {
+		t.Errorf("expected distinct sources, got %v", sources)
+	}
+}
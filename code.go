@@ -0,0 +1,100 @@
+package golog
+
+import "sync"
+
+// CodeInfo documents a message code for support teams.
+type CodeInfo struct {
+	Description string
+	RunbookURL  string
+}
+
+var codesMu sync.RWMutex
+var codes = map[string]CodeInfo{}
+
+// RegisterCode documents code. Entries logged with it carry the description
+// and runbook URL as "code_description" and "runbook_url" fields.
+func RegisterCode(code string, info CodeInfo) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+
+	codes[code] = info
+}
+
+// CodedLogger logs entries tagged with a stable message code, which is
+// attached as the "code" field.
+type CodedLogger struct {
+	gl   *GoLog
+	code string
+}
+
+// Code returns a logger for entries with the given code:
+//
+//	golog.Std.Code("DB0042").Error("connection pool exhausted")
+func (gl *GoLog) Code(code string) *CodedLogger {
+	return &CodedLogger{gl: gl, code: code}
+}
+
+func (cl *CodedLogger) write(entry *Entry) {
+	if entry == nil {
+		return
+	}
+
+	entry.Fields = append(entry.Fields, Field{Key: "code", Value: cl.code})
+
+	codesMu.RLock()
+	info, ok := codes[cl.code]
+	codesMu.RUnlock()
+
+	if ok {
+		if info.Description != "" {
+			entry.Fields = append(entry.Fields, Field{Key: "code_description", Value: info.Description})
+		}
+		if info.RunbookURL != "" {
+			entry.Fields = append(entry.Fields, Field{Key: "runbook_url", Value: info.RunbookURL})
+		}
+	}
+
+	cl.gl.write(entry)
+}
+
+func (cl *CodedLogger) Trace(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LTrace, text, args))
+}
+
+func (cl *CodedLogger) Debug(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LDebug, text, args))
+}
+
+func (cl *CodedLogger) Info(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LInfo, text, args))
+}
+
+func (cl *CodedLogger) Notice(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LNotice, text, args))
+}
+
+func (cl *CodedLogger) Warn(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LWarning, text, args))
+}
+
+func (cl *CodedLogger) Error(text string, args ...interface{}) {
+	cl.write(cl.gl.newEntry(LError, text, args))
+}
+
+func (cl *CodedLogger) DPanic(text string, args ...interface{}) {
+	entry := cl.gl.newEntry(LDPanic, text, args)
+	cl.write(entry)
+	cl.gl.dpanic(entry)
+}
+
+func (cl *CodedLogger) Panic(text string, args ...interface{}) {
+	entry := cl.gl.newEntry(LPanic, text, args)
+	cl.write(entry)
+	cl.gl.panicEntry(entry)
+}
+
+func (cl *CodedLogger) Fatal(text string, args ...interface{}) {
+	entry := cl.gl.newEntry(LFatal, text, args)
+	cl.write(entry)
+	cl.gl.fatal(entry)
+}
@@ -0,0 +1,46 @@
+package golog_test
+
+import (
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestCode(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.RegisterCode("DB0042", golog.CodeInfo{
+		Description: "connection pool exhausted",
+		RunbookURL:  "https://runbooks.example.com/DB0042",
+	})
+
+	golog.Std.Code("DB0042").Error("no connection for %s", "users")
+	golog.Std.Code("UNREGISTERED").Warn("plain")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+
+	want := []golog.Field{
+		{Key: "code", Value: "DB0042"},
+		{Key: "code_description", Value: "connection pool exhausted"},
+		{Key: "runbook_url", Value: "https://runbooks.example.com/DB0042"},
+	}
+	entry := sink.entries[0]
+	if entry.Message != "no connection for users" || entry.Caller != "code_test.go:21" || len(entry.Fields) != len(want) {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	for i, field := range want {
+		if entry.Fields[i] != field {
+			t.Errorf("field %d: got %+v, want %+v", i, entry.Fields[i], field)
+		}
+	}
+
+	if fields := sink.entries[1].Fields; len(fields) != 1 || fields[0].Value != "UNREGISTERED" {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+}
@@ -0,0 +1,159 @@
+package golog
+
+import (
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// ColorDepth is how many colors a terminal displays.
+type ColorDepth uint8
+
+const (
+	// ColorDepth16 is the 8 basic ANSI colors and their bright variants.
+	ColorDepth16 ColorDepth = iota
+
+	// ColorDepth256 is the xterm palette of 256 colors.
+	ColorDepth256
+
+	// ColorDepthTrue is 24-bit RGB.
+	ColorDepthTrue
+)
+
+// TerminalColorDepth reports the colors the terminal advertises:
+// ColorDepthTrue when COLORTERM is "truecolor" or "24bit", ColorDepth256
+// when TERM names a 256-color terminal, ColorDepth16 otherwise.
+func TerminalColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorDepthTrue
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case strings.HasSuffix(term, "-direct"):
+		return ColorDepthTrue
+	case strings.Contains(term, "256color"):
+		return ColorDepth256
+	}
+
+	return ColorDepth16
+}
+
+// RGB returns a 24-bit foreground color for themes that need subtler
+// shades than the basic ANSI colors, e.g.
+//
+//	golog.Std.SetLevelColor(golog.LNotice, golog.RGB(0xb4, 0x8e, 0xad))
+//
+// On terminals without true color, see TerminalColorDepth, it falls back to
+// the closest color of the 256-color palette or of the 16 basic colors.
+func RGB(r, g, b uint8) *color.Color {
+	return TerminalColorDepth().RGB(r, g, b)
+}
+
+// Color256 returns color n of the xterm 256-color palette as foreground,
+// falling back to the closest of the 16 basic colors on terminals without
+// 256 colors.
+func Color256(n uint8) *color.Color {
+	return TerminalColorDepth().Color256(n)
+}
+
+// RGB is the color closest to r, g, b at depth d.
+func (d ColorDepth) RGB(r, g, b uint8) *color.Color {
+	switch d {
+	case ColorDepthTrue:
+		return color.New(38, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b))
+	case ColorDepth256:
+		return color.New(38, 5, color.Attribute(nearestColor256(r, g, b)))
+	}
+
+	return color.New(nearestColor16(r, g, b))
+}
+
+// Color256 is color n of the 256-color palette at depth d.
+func (d ColorDepth) Color256(n uint8) *color.Color {
+	if d >= ColorDepth256 {
+		return color.New(38, 5, color.Attribute(n))
+	}
+
+	r, g, b := paletteRGB(n)
+	return color.New(nearestColor16(r, g, b))
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube at 16-231.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the RGB value of color n in the xterm palette.
+func paletteRGB(n uint8) (uint8, uint8, uint8) {
+	switch {
+	case n < 16:
+		c := basicColors[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	}
+
+	gray := 8 + (n-232)*10
+	return gray, gray, gray
+}
+
+// nearestColor256 returns the closest color of the cube or the gray ramp;
+// the 16 basic colors are skipped as terminals redefine them.
+func nearestColor256(r, g, b uint8) uint8 {
+	cube := func(v uint8) uint8 {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	n := 16 + 36*cube(r) + 6*cube(g) + cube(b)
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	gray := uint8(232)
+	if avg > 238 {
+		gray = 255
+	} else if avg > 8 {
+		gray = 232 + uint8((avg-8)/10)
+	}
+
+	gr, gg, gb := paletteRGB(gray)
+	cr, cg, cb := paletteRGB(n)
+	if colorDistance(gr, gg, gb, r, g, b) < colorDistance(cr, cg, cb, r, g, b) {
+		return gray
+	}
+
+	return n
+}
+
+// basicColors are the xterm defaults of the 16 basic colors, in palette
+// order.
+var basicColors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func nearestColor16(r, g, b uint8) color.Attribute {
+	best, bestDistance := 0, -1
+	for i, c := range basicColors {
+		if d := colorDistance(c[0], c[1], c[2], r, g, b); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+
+	if best < 8 {
+		return color.FgBlack + color.Attribute(best)
+	}
+	return color.FgHiBlack + color.Attribute(best-8)
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
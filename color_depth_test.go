@@ -0,0 +1,55 @@
+package golog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/miyaizu/golog"
+)
+
+func TestTerminalColorDepth(t *testing.T) {
+	for _, test := range []struct {
+		colorterm, term string
+		want            golog.ColorDepth
+	}{
+		{"truecolor", "xterm-256color", golog.ColorDepthTrue},
+		{"24bit", "", golog.ColorDepthTrue},
+		{"", "xterm-direct", golog.ColorDepthTrue},
+		{"", "screen-256color", golog.ColorDepth256},
+		{"", "xterm", golog.ColorDepth16},
+		{"", "", golog.ColorDepth16},
+	} {
+		t.Setenv("COLORTERM", test.colorterm)
+		t.Setenv("TERM", test.term)
+		if depth := golog.TerminalColorDepth(); depth != test.want {
+			t.Errorf("COLORTERM=%q TERM=%q: got %v, want %v", test.colorterm, test.term, depth, test.want)
+		}
+	}
+}
+
+func TestColorFallback(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	for _, test := range []struct {
+		c    *color.Color
+		want string
+	}{
+		{golog.ColorDepthTrue.RGB(0xb4, 0x8e, 0xad), "\x1b[38;2;180;142;173mx"},
+		{golog.ColorDepth256.RGB(0xb4, 0x8e, 0xad), "\x1b[38;5;139mx"},
+		{golog.ColorDepth256.RGB(0x80, 0x80, 0x80), "\x1b[38;5;244mx"},
+		{golog.ColorDepth16.RGB(0xb4, 0x8e, 0xad), "\x1b[90mx"},
+		{golog.ColorDepth16.RGB(0xf0, 0x10, 0x10), "\x1b[91mx"},
+		{golog.ColorDepthTrue.Color256(208), "\x1b[38;5;208mx"},
+		{golog.ColorDepth16.Color256(196), "\x1b[91mx"},
+		{golog.ColorDepth16.Color256(2), "\x1b[32mx"},
+	} {
+		// Only the sequence is compared, resets differ between versions of
+		// the color package.
+		if got := test.c.Sprint("x"); !strings.HasPrefix(got, test.want+"\x1b[0") {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package golog
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// terminals records which outputs of a logger are color terminals, so
+// colors are only written where they will be displayed.
+type terminals struct {
+	out    bool
+	errOut bool
+	levels map[Level]bool
+}
+
+func (gl *GoLog) detectTerminals() {
+	gl.terminals.out = colorTerminal(gl.out)
+	gl.terminals.errOut = gl.terminals.out
+	if gl.errOut != nil {
+		gl.terminals.errOut = colorTerminal(gl.errOut)
+	}
+
+	gl.terminals.levels = make(map[Level]bool, len(gl.levelOuts))
+	for level, w := range gl.levelOuts {
+		gl.terminals.levels[level] = colorTerminal(w)
+	}
+}
+
+// at reports whether the output of entries at level is a color terminal;
+// see GoLog.writeLine.
+func (t terminals) at(level Level) bool {
+	if tty, ok := t.levels[level]; ok {
+		return tty
+	}
+	if level >= LWarning {
+		return t.errOut
+	}
+
+	return t.out
+}
+
+// colorTerminal reports whether w takes colors: a terminal other than a
+// dumb one, with NO_COLOR unset (https://no-color.org). On Windows the
+// console must support escape sequences, which it is switched to.
+// FORCE_COLOR=1 or 0 overrides the detection.
+func colorTerminal(w io.Writer) bool {
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0"
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if isatty.IsCygwinTerminal(file.Fd()) {
+		return true
+	}
+
+	return isatty.IsTerminal(file.Fd()) && enableVirtualTerminal(file)
+}
+
+// SetForceColor writes colors even when the output is not a terminal or
+// NO_COLOR is set, e.g. for a pager that renders them. Colors still need
+// Colorize.
+func (gl *GoLog) SetForceColor(force bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.forceColor = force
+	gl.publish()
+}
@@ -0,0 +1,55 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestColorDetection(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		noColor   string
+		forceEnv  string
+		force     bool
+		wantColor bool
+	}{
+		{"pipe", "", "", false, false},
+		{"force", "", "", true, true},
+		{"force over NO_COLOR", "1", "", true, true},
+		{"FORCE_COLOR", "", "1", false, true},
+		{"force over FORCE_COLOR=0", "", "0", true, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", test.noColor)
+			t.Setenv("FORCE_COLOR", test.forceEnv)
+			golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo, Colorize: true})
+			golog.SetOutput(golog.OStdout)
+
+			var out bytes.Buffer
+			golog.Std.SetLevelOutput(golog.LError, &out)
+			golog.Std.SetForceColor(test.force)
+			golog.Error("failed")
+
+			if color := strings.Contains(out.String(), "\x1b["); color != test.wantColor {
+				t.Errorf("colors %v, want %v: %q", color, test.wantColor, out.String())
+			}
+		})
+	}
+}
+
+func TestColorDetectionColorize(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LError, &out)
+	golog.Std.SetForceColor(true)
+	golog.Error("failed")
+
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Errorf("colors without Colorize: %q", out.String())
+	}
+}
@@ -0,0 +1,144 @@
+package golog
+
+import (
+	"io"
+	texttemplate "text/template"
+	"time"
+)
+
+// config is an immutable snapshot of a logger's settings. Setters change
+// the GoLog under its mutex and publish a fresh snapshot; the write path
+// loads the snapshot once per entry, so it never sees half of a change and
+// takes no locks to read settings.
+type config struct {
+	minLevel        Level
+	defaultLevel    Level
+	sampledMinLevel Level
+
+	colorize      bool
+	forceColor    bool
+	terminals     terminals
+	colorMode     ColorMode
+	format        Format
+	header        *texttemplate.Template
+	userHeader    string
+	icons         IconSet
+	levelLabels   map[Level]string
+	levelColors   Theme
+	wrap          int
+	timeFormat    string
+	utc           bool
+	timePrecision TimePrecision
+	app           string
+	version       string
+	goroutineIDs  bool
+	prefix        string
+	multiline     MultilineMode
+	hexLimit      int
+
+	entryIDs       EntryIDKind
+	callerFormat   CallerFormat
+	callerFunction bool
+	noCaller       bool
+	callerRoot     string
+	callerLink     *texttemplate.Template
+	hyperlinks     bool
+	development    bool
+	exitCode       int
+	exitFunc       func(code int)
+	exitHooks      []func(entry Entry)
+	fingerprints   bool
+	clock          func() time.Time
+	rand           *Rand
+
+	name        string
+	sinks       []sinkEntry
+	escalations []*escalation
+	profiles    []levelProfile
+	budget      *budget
+	digest      *digest
+	limiter     *rateLimiter
+	repeats     *repeatFilter
+	async       *asyncWriter
+	samplers    map[Level]*sampler
+	callers     *callerFilter
+	fileLevels  *fileLevels
+	levelOuts   map[Level]io.Writer
+	buffer      *bufferedWriter
+	pprofLabels bool
+}
+
+// publish stores a snapshot of the current settings and those of named
+// loggers, which inherit part of them; gl.mu must be held.
+func (gl *GoLog) publish() {
+	c := &config{
+		minLevel:        gl.MinLevel,
+		defaultLevel:    gl.DefaultLevel,
+		sampledMinLevel: gl.SampledMinLevel,
+		colorize:        gl.Colorize,
+		forceColor:      gl.forceColor,
+		terminals:       gl.terminals,
+		colorMode:       gl.ColorMode,
+		format:          gl.Format,
+		header:          gl.Header,
+		userHeader:      gl.UserHeader,
+		icons:           gl.icons,
+		levelLabels:     gl.levelLabels,
+		levelColors:     gl.levelColors,
+		wrap:            gl.wrap,
+		timeFormat:      gl.timeFormat,
+		utc:             gl.utc,
+		timePrecision:   gl.precision,
+		app:             gl.app,
+		version:         gl.version,
+		goroutineIDs:    gl.goroutines,
+		prefix:          gl.prefix,
+		multiline:       gl.multiline,
+		hexLimit:        gl.hexLimit,
+		entryIDs:        gl.EntryIDs,
+		callerFormat:    gl.CallerFormat,
+		callerFunction:  gl.callerFunction,
+		noCaller:        gl.noCaller,
+		callerRoot:      gl.CallerRoot,
+		callerLink:      gl.callerLink,
+		hyperlinks:      gl.hyperlinks,
+		development:     gl.Development,
+		exitCode:        gl.exitCode,
+		exitFunc:        gl.exitFunc,
+		exitHooks:       gl.exitHooks,
+		fingerprints:    gl.Fingerprints,
+		clock:           gl.clock,
+		rand:            gl.rand,
+		name:            gl.name,
+		sinks:           gl.sinks,
+		escalations:     gl.escalations,
+		profiles:        gl.profiles,
+		budget:          gl.budget,
+		digest:          gl.digest,
+		limiter:         gl.limiter,
+		repeats:         gl.repeats,
+		async:           gl.async,
+		samplers:        gl.samplers,
+		callers:         gl.callers,
+		fileLevels:      gl.fileLevels,
+		levelOuts:       gl.levelOuts,
+		buffer:          gl.buffer,
+		pprofLabels:     gl.pprofLabels,
+	}
+	gl.inherit(c)
+	gl.cfg.Store(c)
+
+	for _, child := range gl.children {
+		child.mu.Lock()
+		child.publish()
+		child.mu.Unlock()
+	}
+}
+
+func (gl *GoLog) config() *config {
+	if c, ok := gl.cfg.Load().(*config); ok {
+		return c
+	}
+
+	return &config{}
+}
@@ -0,0 +1,334 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// FileConfig is the content of a file read by SetupFromFile, e.g. in YAML:
+//
+//	level: info
+//	format: json
+//	output: split
+//	theme: dark
+//	files:
+//	  - path: /var/log/app/app.log
+//	    level: debug
+//	    rotate: daily
+//	    max_backups: 7
+//	    compress: true
+//	loggers:
+//	  db:
+//	    level: debug
+//	  http.access:
+//	    format: text
+//
+// Unset settings keep the defaults of SetupLogger(nil), except that the
+// environment is not consulted; colors are on unless NO_COLOR is set.
+type FileConfig struct {
+	Level       string                  `json:"level" yaml:"level"`
+	Format      string                  `json:"format" yaml:"format"`
+	Output      string                  `json:"output" yaml:"output"`
+	Color       *bool                   `json:"color" yaml:"color"`
+	Theme       string                  `json:"theme" yaml:"theme"`
+	Development bool                    `json:"development" yaml:"development"`
+	Files       []FileOutputConfig      `json:"files" yaml:"files"`
+	Loggers     map[string]LoggerConfig `json:"loggers" yaml:"loggers"`
+}
+
+// FileOutputConfig adds a FileSink. Level is the sink's own minimum level,
+// as with AddSinkLevel; without it the sink follows the logger's.
+type FileOutputConfig struct {
+	Path       string `json:"path" yaml:"path"`
+	Level      string `json:"level" yaml:"level"`
+	Format     string `json:"format" yaml:"format"`
+	MaxSize    int64  `json:"max_size" yaml:"max_size"`
+	MaxBackups int    `json:"max_backups" yaml:"max_backups"`
+	Rotate     string `json:"rotate" yaml:"rotate"`
+	Compress   bool   `json:"compress" yaml:"compress"`
+	Shared     bool   `json:"shared" yaml:"shared"`
+}
+
+// LoggerConfig overrides settings of the named logger from GetLogger.
+type LoggerConfig struct {
+	Level  string `json:"level" yaml:"level"`
+	Format string `json:"format" yaml:"format"`
+	Color  *bool  `json:"color" yaml:"color"`
+	Theme  string `json:"theme" yaml:"theme"`
+}
+
+// SetupFromFile configures logging from a YAML (.yaml, .yml), TOML (.toml)
+// or JSON (.json) file, see FileConfig, so deployments can template it per
+// environment. It sets up Std, Err and Split as SetupLogger does and
+// attaches the file sinks to them; loggers already registered with
+// GetLogger keep their output but take the new levels and format, and write
+// to the sinks through the current logger.
+// Nothing changes if the file is invalid.
+func SetupFromFile(path string) error {
+	cfg, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := SetupFromConfig(cfg); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	return nil
+}
+
+func readConfigFile(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg FileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+	case ".toml":
+		err = decodeTOML(data, &cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	default:
+		return nil, fmt.Errorf("golog: unknown config file type %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("golog: %s: %v", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// SetupFromConfig applies cfg as SetupFromFile does.
+func SetupFromConfig(cfg *FileConfig) error {
+	rc, err := resolveConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	SetupLogger(rc.option)
+	rc.apply()
+
+	return nil
+}
+
+var configMu sync.Mutex
+
+// configSinks are the sinks opened by the config applied last.
+var configSinks []sinkEntry
+
+// resolvedConfig is a validated FileConfig with its sinks open.
+type resolvedConfig struct {
+	option  *GoLogOption
+	output  Output
+	loggers map[string]LoggerConfig
+	sinks   []sinkEntry
+}
+
+func resolveConfig(cfg *FileConfig) (*resolvedConfig, error) {
+	rc := &resolvedConfig{
+		option: &GoLogOption{
+			Colorize:    os.Getenv("NO_COLOR") == "",
+			MinLevel:    LDebug,
+			Development: cfg.Development,
+		},
+		output:  currentOutput,
+		loggers: cfg.Loggers,
+	}
+	if cfg.Color != nil {
+		rc.option.Colorize = *cfg.Color
+	}
+
+	var err error
+	if cfg.Level != "" {
+		if rc.option.MinLevel, err = ParseLevel(cfg.Level); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Format != "" {
+		if rc.option.Format, err = parseFormat(cfg.Format); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Theme != "" {
+		if rc.option.Theme, err = parseTheme(cfg.Theme); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Output != "" {
+		if rc.output, err = parseOutput(cfg.Output); err != nil {
+			return nil, err
+		}
+	}
+	for name, lc := range cfg.Loggers {
+		if _, err := lc.override(*rc.option); err != nil {
+			return nil, fmt.Errorf("logger %q: %v", name, err)
+		}
+	}
+
+	if rc.sinks, err = openFileOutputs(cfg.Files); err != nil {
+		return nil, err
+	}
+
+	return rc, nil
+}
+
+// apply switches Std, Err, Split and the registered loggers to rc, each
+// under a single lock, and closes the sinks of the previous config.
+func (rc *resolvedConfig) apply() {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if rc.output != currentOutput {
+		SetOutput(rc.output)
+	}
+
+	for name := range rc.loggers {
+		GetLogger(name)
+	}
+
+	for _, gl := range []*GoLog{Std, Err, Split} {
+		gl.reconfigure(*rc.option, configSinks, rc.sinks)
+	}
+	for _, name := range LoggerNames() {
+		option := *rc.option
+		if level, ok := patternLevel(name); ok {
+			option.MinLevel = level
+		}
+		if lc, ok := rc.loggers[name]; ok {
+			option, _ = lc.override(option)
+		}
+		// Named loggers reach the sinks through the current logger.
+		GetLogger(name).reconfigure(option, configSinks, nil)
+	}
+
+	for _, se := range configSinks {
+		if err := se.sink.Close(); err != nil {
+			reportSinkError(err)
+		}
+	}
+	configSinks = rc.sinks
+}
+
+// reconfigure sets gl's levels, format and colors from option and replaces
+// the sinks in old with those in sinks, publishing them together.
+func (gl *GoLog) reconfigure(option GoLogOption, old, sinks []sinkEntry) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.MinLevel = option.MinLevel
+	gl.Format = option.Format
+	gl.Colorize = option.Colorize
+	gl.Development = option.Development
+	gl.levelColors = option.Theme
+
+	kept := make([]sinkEntry, 0, len(gl.sinks)+len(sinks))
+	for _, se := range gl.sinks {
+		if !containsSink(old, se.sink) {
+			kept = append(kept, se)
+		}
+	}
+	gl.sinks = append(kept, sinks...)
+
+	gl.publish()
+}
+
+func containsSink(sinks []sinkEntry, sink Sink) bool {
+	for _, se := range sinks {
+		if se.sink == sink {
+			return true
+		}
+	}
+
+	return false
+}
+
+// override returns option with lc's settings applied.
+func (lc LoggerConfig) override(option GoLogOption) (GoLogOption, error) {
+	var err error
+	if lc.Level != "" {
+		if option.MinLevel, err = ParseLevel(lc.Level); err != nil {
+			return option, err
+		}
+	}
+	if lc.Format != "" {
+		if option.Format, err = parseFormat(lc.Format); err != nil {
+			return option, err
+		}
+	}
+	if lc.Color != nil {
+		option.Colorize = *lc.Color
+	}
+	if lc.Theme != "" {
+		if option.Theme, err = parseTheme(lc.Theme); err != nil {
+			return option, err
+		}
+	}
+
+	return option, nil
+}
+
+// openFileOutputs opens the configured file sinks, closing those already
+// opened if one fails.
+func openFileOutputs(files []FileOutputConfig) ([]sinkEntry, error) {
+	var sinks []sinkEntry
+	fail := func(err error) ([]sinkEntry, error) {
+		for _, s := range sinks {
+			s.sink.Close()
+		}
+		return nil, err
+	}
+
+	for _, fc := range files {
+		option := &FileSinkOption{
+			Path:       fc.Path,
+			MaxSize:    fc.MaxSize,
+			MaxBackups: fc.MaxBackups,
+			Compress:   fc.Compress,
+			Shared:     fc.Shared,
+		}
+
+		var err error
+		level := unknownLevel
+		if fc.Level != "" {
+			if level, err = ParseLevel(fc.Level); err != nil {
+				return fail(fmt.Errorf("file %q: %v", fc.Path, err))
+			}
+		}
+		if fc.Format != "" {
+			if option.Format, err = parseFormat(fc.Format); err != nil {
+				return fail(fmt.Errorf("file %q: %v", fc.Path, err))
+			}
+		}
+		switch strings.ToLower(fc.Rotate) {
+		case "", "never":
+		case "hourly":
+			option.Interval = RotateHourly
+		case "daily":
+			option.Interval = RotateDaily
+		default:
+			return fail(fmt.Errorf("file %q: unknown rotation %q", fc.Path, fc.Rotate))
+		}
+
+		sink, err := NewFileSink(option)
+		if err != nil {
+			return fail(err)
+		}
+		sinks = append(sinks, sinkEntry{sink: sink, minLevel: level})
+	}
+
+	return sinks, nil
+}
@@ -0,0 +1,106 @@
+package golog_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetupFromFile(t *testing.T) {
+	defer golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	defer golog.SetOutput(golog.OStdout)
+
+	configs := map[string]string{
+		"logging.yaml": `
+level: warn
+format: json
+output: stderr
+color: false
+files:
+  - path: {{dir}}/app.log
+    level: debug
+    rotate: daily
+loggers:
+  cfg.db:
+    level: trace
+`,
+		"logging.toml": `
+level = "warn" # the console level
+format = "json"
+output = "stderr"
+color = false
+
+[[files]]
+path = "{{dir}}/app.log"
+level = "debug"
+rotate = "daily"
+
+[loggers."cfg.db"]
+level = "trace"
+`,
+		"logging.json": `{
+	"level": "warn", "format": "json", "output": "stderr", "color": false,
+	"files": [{"path": "{{dir}}/app.log", "level": "debug", "rotate": "daily"}],
+	"loggers": {"cfg.db": {"level": "trace"}}
+}`,
+	}
+
+	for name, content := range configs {
+		dir := t.TempDir()
+		path := filepath.Join(dir, name)
+		content = strings.ReplaceAll(content, "{{dir}}", filepath.ToSlash(dir))
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := golog.SetupFromFile(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if gl := golog.Std; gl.MinLevel != golog.LWarning || gl.Format != golog.FormatJSON || gl.Colorize {
+			t.Errorf("%s: settings not applied: %+v", name, gl)
+		}
+		if output := golog.GetCurrentOutput(); output != golog.OStderr {
+			t.Errorf("%s: expected stderr, got %v", name, output)
+		}
+		if level := golog.GetLogger("cfg.db").MinLevel; level != golog.LTrace {
+			t.Errorf("%s: expected cfg.db at trace, got %v", name, level)
+		}
+
+		golog.Debug("kept in the file")
+		golog.GetLogger("cfg.db").Warn("from a named logger")
+		golog.Std.Close()
+
+		data, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+		if !strings.Contains(string(data), "kept in the file") {
+			t.Errorf("%s: unexpected file content %q", name, data)
+		}
+		if n := strings.Count(string(data), "from a named logger"); n != 1 {
+			t.Errorf("%s: named logger entry written %d times", name, n)
+		}
+	}
+}
+
+func TestSetupFromFileInvalid(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"typo.yaml":   "levle: debug\n",
+		"level.toml":  "level = \"loud\"\n",
+		"rotate.json": `{"files": [{"path": "` + filepath.ToSlash(dir) + `/app.log", "rotate": "weekly"}]}`,
+		"logging.ini": "level=debug\n",
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o644)
+
+		if err := golog.SetupFromFile(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if golog.Std.MinLevel != golog.LInfo {
+			t.Errorf("%s: settings changed by an invalid file", name)
+		}
+	}
+}
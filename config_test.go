@@ -0,0 +1,39 @@
+package golog_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestConcurrentConfiguration(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LError})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSinkLevel(sink, golog.LInfo)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			golog.Std.SetMinLevel(golog.LError)
+			golog.Std.SetColorize(i%2 == 0)
+			golog.Std.SetUserHeader("")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			golog.Debug("dropped")
+			golog.Info("kept")
+		}
+	}()
+	wg.Wait()
+
+	if n := len(sink.messages()); n != 100 {
+		t.Errorf("expected 100 entries, got %d", n)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package golog
+
+import "os"
+
+// enableVirtualTerminal reports whether the terminal behind file processes
+// escape sequences, which terminals outside Windows always do.
+func enableVirtualTerminal(file *os.File) bool {
+	return true
+}
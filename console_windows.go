@@ -0,0 +1,27 @@
+//go:build windows
+
+package golog
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on the processing of escape sequences by the
+// console behind file, so colors render in cmd.exe and PowerShell instead
+// of showing as raw sequences. Consoles older than Windows 10 lack it and
+// get no colors.
+func enableVirtualTerminal(file *os.File) bool {
+	handle := windows.Handle(file.Fd())
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,187 @@
+package golog
+
+import (
+	"context"
+	"sync"
+)
+
+var traceSamplerMu sync.RWMutex
+var traceSampler func(ctx context.Context) bool
+
+// SetTraceSampler installs the function that tells whether the trace carried
+// by a context is sampled. It backs GoLog.SampledMinLevel; see the otelgolog
+// package for an OpenTelemetry implementation.
+func SetTraceSampler(sampler func(ctx context.Context) bool) {
+	traceSamplerMu.Lock()
+	defer traceSamplerMu.Unlock()
+
+	traceSampler = sampler
+}
+
+func traceSampled(ctx context.Context) bool {
+	traceSamplerMu.RLock()
+	sampler := traceSampler
+	traceSamplerMu.RUnlock()
+
+	return ctx != nil && sampler != nil && sampler(ctx)
+}
+
+var traceIDs func(ctx context.Context) (traceID, spanID string)
+
+// SetTraceIDs installs the function that extracts the trace and span IDs of
+// the span carried by a context. Entries logged with a context get them as
+// "trace_id" and "span_id" fields for log/trace correlation; see the
+// otelgolog package for an OpenTelemetry implementation.
+func SetTraceIDs(ids func(ctx context.Context) (traceID, spanID string)) {
+	traceSamplerMu.Lock()
+	defer traceSamplerMu.Unlock()
+
+	traceIDs = ids
+}
+
+func attachTraceIDs(ctx context.Context, entry *Entry) {
+	traceSamplerMu.RLock()
+	ids := traceIDs
+	traceSamplerMu.RUnlock()
+
+	if ctx == nil || ids == nil {
+		return
+	}
+
+	traceID, spanID := ids(ctx)
+	if traceID != "" {
+		entry.Fields = append(entry.Fields, Field{Key: "trace_id", Value: traceID})
+	}
+	if spanID != "" {
+		entry.Fields = append(entry.Fields, Field{Key: "span_id", Value: spanID})
+	}
+}
+
+func (gl *GoLog) writeCtx(ctx context.Context, entry *Entry) {
+	if entry == nil {
+		return
+	}
+
+	c := gl.config()
+	if !c.callerAllowed(entry) {
+		return
+	}
+
+	entry.Fields = append(entry.Fields, contextFields(ctx)...)
+	attachTraceIDs(ctx, entry)
+
+	if c.pprofLabels {
+		attachPprofLabels(ctx, entry)
+	}
+
+	if entry.Level < c.minLevelFor(entry) &&
+		c.sampledMinLevel != unknownLevel && entry.Level >= c.sampledMinLevel &&
+		traceSampled(ctx) {
+		gl.emit(c, entry, c.sampledMinLevel)
+		return
+	}
+
+	gl.deliver(c, entry)
+}
+
+func LogCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(logger.config().defaultLevel, text, args))
+}
+
+func TraceCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LTrace, text, args))
+}
+
+func DebugCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LDebug, text, args))
+}
+
+func InfoCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LInfo, text, args))
+}
+
+func NoticeCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LNotice, text, args))
+}
+
+func WarnCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LWarning, text, args))
+}
+
+func ErrorCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.writeCtx(ctx, logger.newEntry(LError, text, args))
+}
+
+func DPanicCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LDPanic, text, args)
+	logger.writeCtx(ctx, entry)
+	logger.dpanic(entry)
+}
+
+func PanicCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LPanic, text, args)
+	logger.writeCtx(ctx, entry)
+	logger.panicEntry(entry)
+}
+
+func FatalCtx(ctx context.Context, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LFatal, text, args)
+	logger.writeCtx(ctx, entry)
+	logger.fatal(entry)
+}
+
+func (gl *GoLog) LogCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(gl.config().defaultLevel, text, args))
+}
+
+func (gl *GoLog) TraceCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LTrace, text, args))
+}
+
+func (gl *GoLog) DebugCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LDebug, text, args))
+}
+
+func (gl *GoLog) InfoCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LInfo, text, args))
+}
+
+func (gl *GoLog) NoticeCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LNotice, text, args))
+}
+
+func (gl *GoLog) WarnCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LWarning, text, args))
+}
+
+func (gl *GoLog) ErrorCtx(ctx context.Context, text string, args ...interface{}) {
+	gl.writeCtx(ctx, gl.newEntry(LError, text, args))
+}
+
+func (gl *GoLog) DPanicCtx(ctx context.Context, text string, args ...interface{}) {
+	entry := gl.newEntry(LDPanic, text, args)
+	gl.writeCtx(ctx, entry)
+	gl.dpanic(entry)
+}
+
+func (gl *GoLog) PanicCtx(ctx context.Context, text string, args ...interface{}) {
+	entry := gl.newEntry(LPanic, text, args)
+	gl.writeCtx(ctx, entry)
+	gl.panicEntry(entry)
+}
+
+func (gl *GoLog) FatalCtx(ctx context.Context, text string, args ...interface{}) {
+	entry := gl.newEntry(LFatal, text, args)
+	gl.writeCtx(ctx, entry)
+	gl.fatal(entry)
+}
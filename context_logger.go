@@ -0,0 +1,45 @@
+package golog
+
+import "context"
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying logger, for request-scoped
+// loggers that travel down call chains:
+//
+//	ctx = golog.NewContext(ctx, golog.Std.With("request_id", id))
+//	...
+//	golog.FromContext(ctx).Info("cache miss")
+func NewContext(ctx context.Context, logger *FieldLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or one for the current
+// logger without fields if there is none.
+func FromContext(ctx context.Context) *FieldLogger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*FieldLogger); ok {
+			return logger
+		}
+	}
+
+	return &FieldLogger{gl: getCurrentLogger()}
+}
+
+// WithFields returns a copy of ctx whose logger adds keysAndValues to the
+// fields of the logger ctx already carries.
+func WithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(keysAndValues...))
+}
+
+// contextFields returns the fields of the logger carried by ctx.
+func contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	if logger, ok := ctx.Value(loggerKey{}).(*FieldLogger); ok {
+		return logger.fields
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package golog_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLoggerContext(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	ctx := golog.NewContext(context.Background(), golog.Std.With("request_id", "r1"))
+	ctx = golog.WithFields(ctx, "user", 42)
+
+	golog.FromContext(ctx).Info("from context")
+	golog.InfoCtx(ctx, "ctx function")
+	golog.FromContext(context.Background()).Info("no logger")
+
+	want := []golog.Field{{Key: "request_id", Value: "r1"}, {Key: "user", Value: 42}}
+	if len(sink.entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(sink.entries))
+	}
+	for _, entry := range sink.entries[:2] {
+		if !reflect.DeepEqual(entry.Fields, want) {
+			t.Errorf("%s: got fields %v, want %v", entry.Message, entry.Fields, want)
+		}
+	}
+	if fields := sink.entries[2].Fields; len(fields) != 0 {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+}
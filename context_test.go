@@ -0,0 +1,68 @@
+package golog_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+type sampledKey struct{}
+
+func TestSampledMinLevel(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.SetTraceSampler(func(ctx context.Context) bool {
+		return ctx.Value(sampledKey{}) != nil
+	})
+	defer golog.SetTraceSampler(nil)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetSampledMinLevel(golog.LDebug)
+
+	sampled := context.WithValue(context.Background(), sampledKey{}, true)
+	golog.DebugCtx(context.Background(), "unsampled debug")
+	golog.DebugCtx(sampled, "sampled debug")
+	golog.TraceCtx(sampled, "sampled trace")
+	golog.InfoCtx(context.Background(), "info")
+
+	want := []string{"sampled debug", "info"}
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type spanKey struct{}
+
+func TestTraceIDs(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.SetTraceIDs(func(ctx context.Context) (string, string) {
+		if ctx.Value(spanKey{}) == nil {
+			return "", ""
+		}
+		return "trace-1", "span-1"
+	})
+	defer golog.SetTraceIDs(nil)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Std.InfoCtx(context.WithValue(context.Background(), spanKey{}, true), "in span")
+	golog.Std.InfoCtx(context.Background(), "no span")
+
+	want := [][]golog.Field{
+		{{Key: "trace_id", Value: "trace-1"}, {Key: "span_id", Value: "span-1"}},
+		nil,
+	}
+	if len(sink.entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(sink.entries))
+	}
+	for i, entry := range sink.entries {
+		if !reflect.DeepEqual(entry.Fields, want[i]) {
+			t.Errorf("entry %d: got fields %v, want %v", i, entry.Fields, want[i])
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package golog
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type digest struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	start time.Time
+	seen  map[digestKey]int
+	timer *time.Timer
+}
+
+type digestKey struct {
+	level   Level
+	message string
+}
+
+// SetDigest collapses repeated messages: within each window only the first
+// entry with a given level and message is written, and when the window
+// ends a summary such as "\"disk full\" occurred 147 times in the last 10s"
+// is logged for every message seen more than once. Unlike consecutive
+// duplicate suppression this also catches interleaved repetition. A zero
+// window disables the digest.
+func (gl *GoLog) SetDigest(window time.Duration) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.digest != nil {
+		gl.digest.mu.Lock()
+		if gl.digest.timer != nil {
+			gl.digest.timer.Stop()
+		}
+		gl.digest.mu.Unlock()
+	}
+
+	gl.digest = nil
+	if window > 0 {
+		gl.digest = &digest{window: window}
+	}
+
+	gl.publish()
+}
+
+// digestAdmit records entry and reports whether it is the first of its kind
+// in the current window.
+func (gl *GoLog) digestAdmit(d *digest, entry *Entry) bool {
+	if d == nil {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// While duplicates are pending, the timer closes the window.
+	if d.timer == nil && (d.start.IsZero() || !entry.Time.Before(d.start.Add(d.window))) {
+		d.start = entry.Time
+		d.seen = make(map[digestKey]int)
+	}
+
+	key := digestKey{level: entry.Level, message: entry.Message}
+	d.seen[key]++
+	if d.seen[key] == 1 {
+		return true
+	}
+
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.start.Add(d.window).Sub(gl.now()), func() {
+			gl.summarizeDigest(d)
+		})
+	}
+
+	return false
+}
+
+// summarizeDigest logs how often each repeated message occurred during the
+// window that just ended, most frequent first and then by message.
+func (gl *GoLog) summarizeDigest(d *digest) {
+	d.mu.Lock()
+	seen := d.seen
+	d.seen = nil
+	d.start = time.Time{}
+	d.timer = nil
+	window := d.window
+	d.mu.Unlock()
+
+	keys := make([]digestKey, 0, len(seen))
+	for key, count := range seen {
+		if count > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if seen[keys[i]] != seen[keys[j]] {
+			return seen[keys[i]] > seen[keys[j]]
+		}
+		if keys[i].message != keys[j].message {
+			return keys[i].message < keys[j].message
+		}
+		return keys[i].level < keys[j].level
+	})
+
+	for _, key := range keys {
+		gl.writeInternal(key.level, sprintf("%q occurred %d times in the last %s", []interface{}{key.message, seen[key], window}))
+	}
+}
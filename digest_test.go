@@ -0,0 +1,50 @@
+package golog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestDigest(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetDigest(50 * time.Millisecond)
+	defer golog.Std.SetDigest(0)
+
+	for i := 0; i < 3; i++ {
+		golog.Warn("disk full")
+		golog.Info("tick")
+		golog.Info("alarm")
+		golog.Warn("disk full")
+	}
+	golog.Error("disk full")
+	golog.Info("once")
+
+	want := []string{"disk full", "tick", "alarm", "disk full", "once"}
+	if messages := sink.messages(); len(messages) != len(want) {
+		t.Fatalf("expected %q, got %q", want, messages)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	messages := sink.messages()
+	if len(messages) != 8 ||
+		messages[5] != `"disk full" occurred 6 times in the last 50ms` ||
+		messages[6] != `"alarm" occurred 3 times in the last 50ms` ||
+		messages[7] != `"tick" occurred 3 times in the last 50ms` {
+		t.Fatalf("unexpected summaries %q", messages[len(want):])
+	}
+	if sink.entries[5].Level != golog.LWarning {
+		t.Errorf("expected the summary at the original level, got %v", sink.entries[5].Level)
+	}
+
+	golog.Warn("disk full")
+	if messages := sink.messages(); messages[len(messages)-1] != "disk full" {
+		t.Errorf("digest did not reset: %q", messages)
+	}
+}
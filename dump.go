@@ -0,0 +1,218 @@
+package golog
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxDumpDepth bounds how deep Dump descends into nested values.
+const maxDumpDepth = 16
+
+// Dump logs v at LDebug, pretty-printed with its types: nested structs,
+// including unexported fields, maps with sorted keys, slices and pointers,
+// one element per line, e.g.
+//
+//	main.Config{
+//	    Name: "api",
+//	    Ports: []int{
+//	        80,
+//	    },
+//	    Parent: (*main.Config)(nil),
+//	    Meta: map[string]interface {}{
+//	        "retries": int(3),
+//	    },
+//	}
+//
+// v is only walked once the entry passes the level filters. Pointers seen
+// before on the same path print as <cycle>.
+func (gl *GoLog) Dump(v interface{}) {
+	gl.write(gl.newEntry(LDebug, "%s", lazyArgs(func() string { return sdump(v) })))
+}
+
+func Dump(v interface{}) {
+	logger := getCurrentLogger()
+	logger.write(logger.newEntry(LDebug, "%s", lazyArgs(func() string { return sdump(v) })))
+}
+
+func sdump(v interface{}) string {
+	d := &dumper{visited: map[uintptr]bool{}}
+	d.value(reflect.ValueOf(v), 0, true)
+
+	return d.b.String()
+}
+
+type dumper struct {
+	b       strings.Builder
+	visited map[uintptr]bool
+}
+
+// value writes v, preceded by its type if typed is set, as it is for the
+// top level and for values held by interfaces.
+func (d *dumper) value(v reflect.Value, depth int, typed bool) {
+	if !v.IsValid() {
+		d.b.WriteString("nil")
+		return
+	}
+
+	// Errors print their message and structs such as time.Time their
+	// String form, which say more than their fields.
+	if v.Kind() != reflect.Interface && v.CanInterface() && !(v.Kind() == reflect.Ptr && v.IsNil()) {
+		switch s := v.Interface().(type) {
+		case error:
+			d.typed(v.Type(), strconv.Quote(s.Error()), true)
+			return
+		case fmt.Stringer:
+			if v.Kind() == reflect.Struct {
+				d.typed(v.Type(), strconv.Quote(s.String()), true)
+				return
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			d.typed(v.Type(), "nil", typed)
+			return
+		}
+		d.value(v.Elem(), depth, true)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			d.typed(v.Type(), "nil", true)
+			return
+		}
+		if d.visited[v.Pointer()] {
+			d.b.WriteString("&" + v.Elem().Type().String() + "<cycle>")
+			return
+		}
+		d.visited[v.Pointer()] = true
+		defer delete(d.visited, v.Pointer())
+
+		d.b.WriteByte('&')
+		d.value(v.Elem(), depth, true)
+
+	case reflect.Struct:
+		d.b.WriteString(v.Type().String())
+		d.open('{', v.NumField(), depth, func(i int) {
+			d.b.WriteString(v.Type().Field(i).Name + ": ")
+			d.value(v.Field(i), depth+1, false)
+		})
+
+	case reflect.Map:
+		if v.IsNil() {
+			d.typed(v.Type(), "nil", typed)
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return sdumpKey(keys[i]) < sdumpKey(keys[j]) })
+
+		d.b.WriteString(v.Type().String())
+		d.open('{', len(keys), depth, func(i int) {
+			d.value(keys[i], depth+1, false)
+			d.b.WriteString(": ")
+			d.value(v.MapIndex(keys[i]), depth+1, false)
+		})
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			d.typed(v.Type(), "nil", typed)
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			d.typed(v.Type(), fmt.Sprintf("%q", v.Bytes()), true)
+			return
+		}
+
+		d.b.WriteString(v.Type().String())
+		d.open('{', v.Len(), depth, func(i int) {
+			d.value(v.Index(i), depth+1, false)
+		})
+
+	case reflect.String:
+		d.typed(v.Type(), strconv.Quote(v.String()), typed && v.Type().Name() != "string")
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			d.typed(v.Type(), "nil", true)
+			return
+		}
+		d.typed(v.Type(), fmt.Sprintf("%#x", v.Pointer()), true)
+
+	default:
+		d.typed(v.Type(), fmt.Sprint(scalar(v)), typed)
+	}
+}
+
+// open writes the n elements of a composite value, one per line, or {...}
+// below maxDumpDepth.
+func (d *dumper) open(brace byte, n, depth int, elem func(i int)) {
+	if n == 0 {
+		d.b.WriteString("{}")
+		return
+	}
+	if depth >= maxDumpDepth {
+		d.b.WriteString("{...}")
+		return
+	}
+
+	indent := strings.Repeat("    ", depth+1)
+	d.b.WriteByte(brace)
+	for i := 0; i < n; i++ {
+		d.b.WriteString("\n" + indent)
+		elem(i)
+		d.b.WriteByte(',')
+	}
+	d.b.WriteString("\n" + indent[4:] + "}")
+}
+
+// typed writes s, as a conversion to t if typed is set.
+func (d *dumper) typed(t reflect.Type, s string, typed bool) {
+	if !typed {
+		d.b.WriteString(s)
+		return
+	}
+
+	name := t.String()
+	if strings.HasPrefix(name, "*") || strings.HasPrefix(name, "func") || strings.HasPrefix(name, "chan") {
+		name = "(" + name + ")"
+	}
+	d.b.WriteString(name + "(" + s + ")")
+}
+
+// scalar returns the value of a boolean or numeric v, which may be an
+// unexported field that cannot be turned into an interface.
+func scalar(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex()
+	}
+
+	return v.String()
+}
+
+// sdumpKey orders map keys by their printed form, numbers numerically.
+func sdumpKey(v reflect.Value) string {
+	switch s := scalar(v).(type) {
+	case int64:
+		return fmt.Sprintf("%020d", uint64(s)^1<<63)
+	case uint64:
+		return fmt.Sprintf("%020d", s)
+	}
+
+	d := &dumper{visited: map[uintptr]bool{}}
+	d.value(v, 0, false)
+
+	return d.b.String()
+}
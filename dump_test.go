@@ -0,0 +1,76 @@
+package golog_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+type dumpNode struct {
+	Name     string
+	Ports    []int
+	Parent   *dumpNode
+	Meta     map[string]interface{}
+	Err      error
+	internal uint8
+}
+
+func TestDump(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LDebug, &out)
+
+	node := &dumpNode{
+		Name:     "api",
+		Ports:    []int{80, 443},
+		Meta:     map[string]interface{}{"retries": 3, "b": nil, "-1": -1.5},
+		Err:      errors.New("down"),
+		internal: 7,
+	}
+	node.Parent = node
+	golog.Std.Dump(node)
+
+	want := `: &golog_test.dumpNode{
+    Name: "api",
+    Ports: []int{
+        80,
+        443,
+    },
+    Parent: &golog_test.dumpNode<cycle>,
+    Meta: map[string]interface {}{
+        "-1": float64(-1.5),
+        "b": nil,
+        "retries": int(3),
+    },
+    Err: (*errors.errorString)("down"),
+    internal: 7,
+}
+`
+	if got := out.String(); !strings.HasSuffix(got, want) || !strings.Contains(got, "(dump_test.go:") {
+		t.Errorf("unexpected dump %q", got)
+	}
+}
+
+func TestDumpLazy(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LDebug, &out)
+
+	golog.Dump(map[int]string{2: "b", -1: "a"})
+	if out.Len() != 0 {
+		t.Errorf("dump below the minimum level written: %q", out.String())
+	}
+
+	golog.Std.SetMinLevel(golog.LDebug)
+	golog.Dump(map[int]string{2: "b", -1: "a"})
+	if got := out.String(); !strings.HasSuffix(got, ": map[int]string{\n    -1: \"a\",\n    2: \"b\",\n}\n") {
+		t.Errorf("unexpected dump %q", got)
+	}
+}
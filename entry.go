@@ -0,0 +1,110 @@
+package golog
+
+import (
+	"time"
+)
+
+type Entry struct {
+	ID      string
+	Level   Level
+	Time    time.Time
+	Caller  string
+	Message string
+
+	// Logger is the name of the logger from GetLogger or Named, if any.
+	Logger string
+
+	// Prefix is the component tag set with SetPrefix, if any.
+	Prefix string
+
+	// Goroutine is the ID of the logging goroutine, 0 unless the logger
+	// was set up with SetGoroutineIDs.
+	Goroutine uint64
+
+	// File, Line and Function locate the call site; Caller is their short
+	// display form.
+	File     string
+	Line     int
+	Function string
+
+	Fields []Field
+
+	// template is the format string Message was rendered from.
+	template string
+
+	// args are formatted into Message by render once the entry is known to
+	// be written; pending is set until then.
+	args    []interface{}
+	pending bool
+}
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Lazy defers computing a value until the entry it is logged with passes
+// level filtering and sampling, so expensive values cost nothing when the
+// entry is dropped. Use it as a format argument or field value:
+//
+//	golog.Debug("state: %v", golog.Lazy(func() interface{} { return dump(state) }))
+//
+// fmt.Stringer arguments are deferred the same way without wrapping.
+type Lazy func() interface{}
+
+// render formats the message and resolves Lazy field values.
+func (entry *Entry) render() {
+	if !entry.pending {
+		return
+	}
+	entry.pending = false
+
+	// args may be the caller's own slice, so it is copied before the
+	// first Lazy is replaced.
+	args := entry.args
+	copied := false
+	for i, arg := range args {
+		if lazy, ok := arg.(Lazy); ok {
+			if !copied {
+				args = append([]interface{}(nil), args...)
+				copied = true
+			}
+			args[i] = lazy()
+		}
+	}
+	entry.Message = sprintf(entry.template, args)
+	entry.args = nil
+
+	for i, field := range entry.Fields {
+		if lazy, ok := field.Value.(Lazy); ok {
+			entry.Fields[i].Value = lazy()
+		}
+	}
+
+	entry.attachErrorStack(args)
+}
+
+// values flattens the entry into a map suitable for JSON encoding. Fields
+// never overwrite the base keys.
+func (entry *Entry) values() map[string]interface{} {
+	values := make(map[string]interface{}, len(entry.Fields)+4)
+	for _, field := range entry.Fields {
+		values[field.Key] = field.Value
+	}
+
+	values["time"] = entry.Time.Format(time.RFC3339Nano)
+	values["level"] = trimLevel(entry.Level)
+	values["caller"] = entry.Caller
+	values["message"] = entry.Message
+	if entry.ID != "" {
+		values["id"] = entry.ID
+	}
+	if entry.Prefix != "" {
+		values["prefix"] = entry.Prefix
+	}
+	if entry.Goroutine != 0 {
+		values["goroutine"] = entry.Goroutine
+	}
+
+	return values
+}
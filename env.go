@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// optionFromEnv applies the environment to option and returns the output
+// to use, for SetupLogger(nil):
+//
+//	GOLOG_LEVEL   minimum level, e.g. "info"
+//	GOLOG_FORMAT  "text", "json" or "gcp"
+//	GOLOG_OUTPUT  "stdout", "stderr" or "split"
+//	GOLOG_THEME   "default", "dark", "light" or "solarized"
+//	NO_COLOR      disables colors when not empty (https://no-color.org)
+//
+// Invalid values are reported on stderr and ignored.
+func optionFromEnv(option *GoLogOption, output Output) Output {
+	if s := os.Getenv("GOLOG_LEVEL"); s != "" {
+		if level, err := ParseLevel(s); err != nil {
+			reportEnvError("GOLOG_LEVEL", err)
+		} else {
+			option.MinLevel = level
+		}
+	}
+
+	if s := os.Getenv("GOLOG_FORMAT"); s != "" {
+		if format, err := parseFormat(s); err != nil {
+			reportEnvError("GOLOG_FORMAT", err)
+		} else {
+			option.Format = format
+		}
+	}
+
+	if s := os.Getenv("GOLOG_OUTPUT"); s != "" {
+		if out, err := parseOutput(s); err != nil {
+			reportEnvError("GOLOG_OUTPUT", err)
+		} else {
+			output = out
+		}
+	}
+
+	if s := os.Getenv("GOLOG_THEME"); s != "" {
+		if theme, err := parseTheme(s); err != nil {
+			reportEnvError("GOLOG_THEME", err)
+		} else {
+			option.Theme = theme
+		}
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		option.Colorize = false
+	}
+
+	return output
+}
+
+func parseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "gcp":
+		return FormatGCP, nil
+	}
+
+	return FormatText, fmt.Errorf("golog: unknown format %q", s)
+}
+
+func parseOutput(s string) (Output, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "stdout":
+		return OStdout, nil
+	case "stderr":
+		return OStderr, nil
+	case "split":
+		return OSplit, nil
+	}
+
+	return unknownOutput, fmt.Errorf("golog: unknown output %q", s)
+}
+
+func parseTheme(s string) (Theme, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "default":
+		return nil, nil
+	case "dark":
+		return DarkTheme, nil
+	case "light":
+		return LightTheme, nil
+	case "solarized":
+		return SolarizedTheme, nil
+	}
+
+	return nil, fmt.Errorf("golog: unknown theme %q", s)
+}
+
+func reportEnvError(name string, err error) {
+	fmt.Fprintf(os.Stderr, "golog: ignoring %s: %v\n", name, err)
+}
@@ -0,0 +1,40 @@
+package golog_test
+
+import (
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetupFromEnv(t *testing.T) {
+	t.Setenv("GOLOG_LEVEL", "WARNING")
+	t.Setenv("GOLOG_FORMAT", "json")
+	t.Setenv("GOLOG_OUTPUT", "split")
+	t.Setenv("NO_COLOR", "1")
+	defer golog.SetOutput(golog.OStdout)
+
+	golog.SetupLogger(nil)
+	if gl := golog.Std; gl.MinLevel != golog.LWarning || gl.Format != golog.FormatJSON || gl.Colorize {
+		t.Errorf("environment not applied: %+v", gl)
+	}
+	if output := golog.GetCurrentOutput(); output != golog.OSplit {
+		t.Errorf("expected the split output, got %v", output)
+	}
+
+	// Explicit options ignore the environment.
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	if golog.Std.MinLevel != golog.LDebug || golog.Std.Format != golog.FormatText {
+		t.Errorf("environment applied to explicit options: %+v", golog.Std)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]golog.Level{"trace": golog.LTrace, " Info ": golog.LInfo, "warn": golog.LWarning, "fatal": golog.LFatal} {
+		if level, err := golog.ParseLevel(s); err != nil || level != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", s, level, err, want)
+		}
+	}
+	if _, err := golog.ParseLevel("loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EscalationRule re-emits an entry at a higher level once entries with the
+// same fingerprint (format string and caller) at Level have been seen
+// Threshold times within Window, so chronic warnings reach error alerting.
+type EscalationRule struct {
+	Level     Level
+	Threshold int
+	Window    time.Duration
+	To        Level
+}
+
+type escalation struct {
+	rule EscalationRule
+
+	mu   sync.Mutex
+	seen map[string][]time.Time
+}
+
+func (gl *GoLog) AddEscalationRule(rule EscalationRule) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.escalations = append(gl.escalations, &escalation{
+		rule: rule,
+		seen: make(map[string][]time.Time),
+	})
+
+	gl.publish()
+}
+
+// escalates reports whether entries at level are watched by an escalation
+// rule, which needs to see them whatever the minimum level.
+func (c *config) escalates(level Level) bool {
+	for _, esc := range c.escalations {
+		if esc.rule.Level == level && esc.rule.Threshold > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// escalate records entry against the escalation rules and returns the entry
+// to re-emit when one of them fires.
+func (c *config) escalate(entry *Entry) *Entry {
+	for _, esc := range c.escalations {
+		if escalated := esc.observe(entry); escalated != nil {
+			return escalated
+		}
+	}
+
+	return nil
+}
+
+func (esc *escalation) observe(entry *Entry) *Entry {
+	rule := esc.rule
+	if entry.Level != rule.Level || rule.Threshold <= 0 {
+		return nil
+	}
+
+	key := fingerprint(entry)
+
+	esc.mu.Lock()
+	defer esc.mu.Unlock()
+
+	// Forget occurrences that fell out of the window, and fingerprints
+	// that have gone quiet, so the map does not grow without bound.
+	cutoff := entry.Time.Add(-rule.Window)
+	for k, times := range esc.seen {
+		i := 0
+		for i < len(times) && times[i].Before(cutoff) {
+			i++
+		}
+		if i == len(times) {
+			delete(esc.seen, k)
+		} else {
+			esc.seen[k] = times[i:]
+		}
+	}
+
+	times := append(esc.seen[key], entry.Time)
+	if len(times) < rule.Threshold {
+		esc.seen[key] = times
+		return nil
+	}
+	delete(esc.seen, key)
+
+	entry.render()
+	escalated := *entry
+	escalated.Level = rule.To
+	escalated.Message = fmt.Sprintf("%s (escalated from %s: %d occurrences in %s)",
+		entry.Message, trimLevel(entry.Level), len(times), rule.Window)
+
+	return &escalated
+}
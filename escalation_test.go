@@ -0,0 +1,43 @@
+package golog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestEscalationRule(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.AddEscalationRule(golog.EscalationRule{
+		Level:     golog.LWarning,
+		Threshold: 3,
+		Window:    time.Minute,
+		To:        golog.LError,
+	})
+
+	for i := 0; i < 4; i++ {
+		golog.Warn("disk usage %d%%", 90+i)
+	}
+	golog.Warn("other warning")
+
+	var warnings, errors int
+	for _, entry := range sink.entries {
+		switch entry.Level {
+		case golog.LWarning:
+			warnings++
+		case golog.LError:
+			errors++
+			if entry.Message != "disk usage 92% (escalated from warn: 3 occurrences in 1m0s)" {
+				t.Errorf("unexpected escalated message %q", entry.Message)
+			}
+		}
+	}
+	if warnings != 5 || errors != 1 {
+		t.Errorf("expected 5 warnings and 1 error, got %d and %d", warnings, errors)
+	}
+}
@@ -0,0 +1,76 @@
+package golog
+
+import "os"
+
+// SetExitCode sets the status that Fatal, logged through gl, exits with; 1
+// by default.
+func (gl *GoLog) SetExitCode(code int) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.exitCode = code
+	gl.publish()
+}
+
+// SetExitFunc replaces os.Exit as the way Fatal, logged through gl, ends
+// the process, e.g. to cancel a root context for a graceful shutdown, or to
+// record the exit in tests of code paths that log fatally. If fn returns, so
+// does Fatal, leaving gl open. nil restores os.Exit.
+func (gl *GoLog) SetExitFunc(fn func(code int)) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.exitFunc = fn
+	gl.publish()
+}
+
+// AddExitHook adds a function run when Fatal or Panic is logged through gl,
+// or RecoverAndLog logs a panic through it, after the hooks registered with
+// RegisterPanicHook and under the same timeout, e.g. to flush a remote sink
+// or dump state for the postmortem.
+func (gl *GoLog) AddExitHook(hook func(entry Entry)) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.exitHooks = append(gl.exitHooks[:len(gl.exitHooks):len(gl.exitHooks)], hook)
+	gl.publish()
+}
+
+func (gl *GoLog) runExitHooks(entry *Entry) {
+	runPanicHooks(entry, gl.config().exitHooks...)
+}
+
+// dpanic panics with the message of a written LDPanic entry in development
+// configuration.
+func (gl *GoLog) dpanic(entry *Entry) {
+	entry.render()
+	if gl.config().development {
+		panic(entry.Message)
+	}
+}
+
+// panicEntry runs the exit hooks for a written LPanic entry and panics
+// with its message.
+func (gl *GoLog) panicEntry(entry *Entry) {
+	gl.Flush()
+	entry.render()
+	gl.runExitHooks(entry)
+	panic(entry.Message)
+}
+
+// fatal runs the exit hooks for a written LFatal entry, flushes the
+// entries still buffered or queued and exits. gl is closed only when the
+// process actually exits, as an exit function may return.
+func (gl *GoLog) fatal(entry *Entry) {
+	entry.render()
+	gl.runExitHooks(entry)
+	gl.Flush()
+
+	c := gl.config()
+	if c.exitFunc != nil {
+		c.exitFunc(c.exitCode)
+		return
+	}
+	gl.Close()
+	os.Exit(c.exitCode)
+}
@@ -0,0 +1,66 @@
+package golog_test
+
+import (
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestExitFunc(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var hooked []string
+	golog.Std.AddExitHook(func(entry golog.Entry) {
+		hooked = append(hooked, entry.Message)
+	})
+
+	code := -1
+	golog.Std.SetExitCode(4)
+	golog.Std.SetExitFunc(func(c int) { code = c })
+
+	golog.Fatal("disk %s", "gone")
+	if code != 4 {
+		t.Errorf("expected exit code 4, got %d", code)
+	}
+
+	func() {
+		defer func() { recover() }()
+		golog.Panic("bad state")
+	}()
+
+	if len(hooked) != 2 || hooked[0] != "disk gone" || hooked[1] != "bad state" {
+		t.Errorf("unexpected hook calls %q", hooked)
+	}
+}
+
+func TestExitMethods(t *testing.T) {
+	sink := &memorySink{}
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.AddSink(sink)
+
+	code := -1
+	gl.SetExitCode(3)
+	gl.SetExitFunc(func(c int) { code = c })
+
+	func() {
+		defer func() {
+			if r := recover(); r != "bad state" {
+				t.Errorf("unexpected panic %v", r)
+			}
+		}()
+		gl.Code("E1").Panic("bad state")
+	}()
+
+	gl.With("user", 7).Fatal("disk %s", "gone")
+	if code != 3 {
+		t.Errorf("expected exit code 3, got %d", code)
+	}
+
+	// The exit function returned, so gl is still open.
+	gl.Error("after exit")
+
+	if got := sink.messages(); len(got) != 3 || got[0] != "bad state" || got[1] != "disk gone" || got[2] != "after exit" {
+		t.Errorf("unexpected entries %q", got)
+	}
+}
@@ -0,0 +1,9 @@
+package golog
+
+// NamedLoggers returns how many named loggers gl keeps track of.
+func NamedLoggers(gl *GoLog) int {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	return len(gl.children)
+}
@@ -0,0 +1,172 @@
+package golog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldLogger logs entries carrying a fixed set of structured fields:
+//
+//	golog.Std.With("user", id, "req", reqID).Info("login ok")
+//
+// Fields go to sinks and the JSON format as they are, and are appended to
+// text lines as key=value pairs in the order they were given.
+type FieldLogger struct {
+	gl     *GoLog
+	fields []Field
+}
+
+// With returns a logger that adds the given alternating keys and values to
+// every entry. A Field can be passed in place of a key and value.
+func (gl *GoLog) With(keysAndValues ...interface{}) *FieldLogger {
+	return &FieldLogger{gl: gl, fields: keyValueFields(nil, keysAndValues)}
+}
+
+// With returns a logger with keysAndValues added to fl's fields.
+func (fl *FieldLogger) With(keysAndValues ...interface{}) *FieldLogger {
+	fields := append([]Field(nil), fl.fields...)
+	return &FieldLogger{gl: fl.gl, fields: keyValueFields(fields, keysAndValues)}
+}
+
+func (fl *FieldLogger) write(entry *Entry) {
+	if entry == nil {
+		return
+	}
+
+	entry.Fields = append(entry.Fields, fl.fields...)
+	fl.gl.write(entry)
+}
+
+func (fl *FieldLogger) Trace(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LTrace, text, args))
+}
+
+func (fl *FieldLogger) Debug(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LDebug, text, args))
+}
+
+func (fl *FieldLogger) Info(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LInfo, text, args))
+}
+
+func (fl *FieldLogger) Notice(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LNotice, text, args))
+}
+
+func (fl *FieldLogger) Warn(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LWarning, text, args))
+}
+
+func (fl *FieldLogger) Error(text string, args ...interface{}) {
+	fl.write(fl.gl.newEntry(LError, text, args))
+}
+
+func (fl *FieldLogger) DPanic(text string, args ...interface{}) {
+	entry := fl.gl.newEntry(LDPanic, text, args)
+	fl.write(entry)
+	fl.gl.dpanic(entry)
+}
+
+func (fl *FieldLogger) Panic(text string, args ...interface{}) {
+	entry := fl.gl.newEntry(LPanic, text, args)
+	fl.write(entry)
+	fl.gl.panicEntry(entry)
+}
+
+func (fl *FieldLogger) Fatal(text string, args ...interface{}) {
+	entry := fl.gl.newEntry(LFatal, text, args)
+	fl.write(entry)
+	fl.gl.fatal(entry)
+}
+
+// writew logs message as is, not as a format string, with the given fields.
+func (gl *GoLog) writew(entry *Entry, keysAndValues []interface{}) {
+	if entry == nil {
+		return
+	}
+
+	entry.Fields = keyValueFields(entry.Fields, keysAndValues)
+	gl.write(entry)
+}
+
+func (gl *GoLog) Tracew(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LTrace, "%s", []interface{}{message}), keysAndValues)
+}
+
+func (gl *GoLog) Debugw(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LDebug, "%s", []interface{}{message}), keysAndValues)
+}
+
+func (gl *GoLog) Infow(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LInfo, "%s", []interface{}{message}), keysAndValues)
+}
+
+func (gl *GoLog) Noticew(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LNotice, "%s", []interface{}{message}), keysAndValues)
+}
+
+func (gl *GoLog) Warnw(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LWarning, "%s", []interface{}{message}), keysAndValues)
+}
+
+func (gl *GoLog) Errorw(message string, keysAndValues ...interface{}) {
+	gl.writew(gl.newEntry(LError, "%s", []interface{}{message}), keysAndValues)
+}
+
+// keyValueFields appends alternating keys and values to fields. Keys that
+// are not strings are formatted with fmt.Sprint; a key without a value
+// gets "(MISSING)".
+func keyValueFields(fields []Field, keysAndValues []interface{}) []Field {
+	for i := 0; i < len(keysAndValues); i++ {
+		if field, ok := keysAndValues[i].(Field); ok {
+			fields = append(fields, field)
+			continue
+		}
+
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keysAndValues) {
+			i++
+			value = keysAndValues[i]
+		}
+		fields = append(fields, Field{Key: key, Value: value})
+	}
+
+	return fields
+}
+
+// textMessage is the message followed by the fields as key=value pairs,
+// for text output. The stack trace is rendered separately by stackSuffix.
+func (entry *Entry) textMessage() string {
+	if len(entry.Fields) == 0 {
+		return entry.Message
+	}
+
+	var b strings.Builder
+	b.WriteString(entry.Message)
+	for _, field := range entry.Fields {
+		if field.Key == "stacktrace" {
+			continue
+		}
+
+		b.WriteByte(' ')
+		b.WriteString(quoteFieldText(field.Key))
+		b.WriteByte('=')
+		b.WriteString(quoteFieldText(fmt.Sprint(field.Value)))
+	}
+
+	return b.String()
+}
+
+func quoteFieldText(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n\r") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
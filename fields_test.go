@@ -0,0 +1,63 @@
+package golog_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWith(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fileSink, err := golog.NewFileSink(&golog.FileSinkOption{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golog.Std.AddSink(fileSink)
+
+	logger := golog.Std.With("user", 42, "req", "r-1")
+	logger.With("step", "two words").Info("login %s", "ok")
+	logger.Debug("dropped")
+	golog.Std.Infow("100% done", "user", 7, golog.Field{Key: "zone", Value: "a"}, "odd")
+	fileSink.Close()
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+
+	want := []golog.Field{{Key: "user", Value: 42}, {Key: "req", Value: "r-1"}, {Key: "step", Value: "two words"}}
+	entry := sink.entries[0]
+	if entry.Message != "login ok" || entry.Caller != "fields_test.go:27" || len(entry.Fields) != len(want) {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	for i, field := range want {
+		if entry.Fields[i] != field {
+			t.Errorf("field %d: got %+v, want %+v", i, entry.Fields[i], field)
+		}
+	}
+
+	entry = sink.entries[1]
+	if entry.Message != "100% done" || entry.Caller != "fields_test.go:29" || len(entry.Fields) != 3 || entry.Fields[2].Value != "(MISSING)" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if !strings.HasSuffix(lines[0], `: login ok user=42 req=r-1 step="two words"`) {
+		t.Errorf("unexpected line %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `: 100% done user=7 zone=a odd=(MISSING)`) {
+		t.Errorf("unexpected line %q", lines[1])
+	}
+}
@@ -0,0 +1,136 @@
+package golog
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Caller patterns are matched against the caller's package import path and
+// its source file path. "*" matches within one path element, "..." matches
+// anything, and a trailing "/..." also matches the path without it. Unless
+// a pattern starts with "/" it may match any trailing part of a path, so
+// "github.com/noisy/lib/..." covers the package and its subpackages,
+// "vendor/..." covers every vendored file and "store/*.go" the files of any
+// store directory.
+type callerFilter struct {
+	allow []*regexp.Regexp
+	deny  []*regexp.Regexp
+
+	decisions sync.Map
+}
+
+// AllowCallers restricts the logger to entries from callers matching one of
+// the patterns.
+func (gl *GoLog) AllowCallers(patterns ...string) {
+	gl.updateCallerFilter(func(f *callerFilter) {
+		f.allow = append(f.allow, compileCallerPatterns(patterns)...)
+	})
+}
+
+// DenyCallers drops entries from callers matching any of the patterns. Deny
+// patterns win over allow patterns.
+func (gl *GoLog) DenyCallers(patterns ...string) {
+	gl.updateCallerFilter(func(f *callerFilter) {
+		f.deny = append(f.deny, compileCallerPatterns(patterns)...)
+	})
+}
+
+func (gl *GoLog) ResetCallerFilters() {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.callers = nil
+	gl.publish()
+}
+
+// updateCallerFilter swaps in a new filter so cached decisions never
+// outlive the patterns they were made with.
+func (gl *GoLog) updateCallerFilter(update func(f *callerFilter)) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	f := &callerFilter{}
+	if gl.callers != nil {
+		f.allow = gl.callers.allow
+		f.deny = gl.callers.deny
+	}
+	update(f)
+
+	gl.callers = f
+	gl.publish()
+}
+
+func compileCallerPatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		res = append(res, compileCallerPattern(pattern))
+	}
+
+	return res
+}
+
+func compileCallerPattern(pattern string) *regexp.Regexp {
+	suffix := ""
+	if strings.HasSuffix(pattern, "/...") {
+		pattern = strings.TrimSuffix(pattern, "/...")
+		suffix = "(/.*)?"
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.Replace(expr, `\.\.\.`, `.*`, -1)
+	expr = strings.Replace(expr, `\*`, `[^/]*`, -1)
+	expr = strings.Replace(expr, `\?`, `[^/]`, -1)
+
+	prefix := "^"
+	if !strings.HasPrefix(pattern, "/") {
+		prefix = "^(.*/)?"
+	}
+
+	return regexp.MustCompile(prefix + expr + suffix + "$")
+}
+
+func (c *config) callerAllowed(entry *Entry) bool {
+	f := c.callers
+	if f == nil {
+		return true
+	}
+
+	key := entry.File + "\x00" + entry.Function
+	if allowed, ok := f.decisions.Load(key); ok {
+		return allowed.(bool)
+	}
+
+	allowed := f.decide(entry.File, packageOf(entry.Function))
+	f.decisions.Store(key, allowed)
+
+	return allowed
+}
+
+func (f *callerFilter) decide(file, pkg string) bool {
+	matches := func(res []*regexp.Regexp) bool {
+		for _, re := range res {
+			if re.MatchString(pkg) || re.MatchString(file) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matches(f.deny) {
+		return false
+	}
+
+	return len(f.allow) == 0 || matches(f.allow)
+}
+
+// packageOf extracts the import path from a function name such as
+// "github.com/user/repo/pkg.(*Type).Method".
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+
+	return function
+}
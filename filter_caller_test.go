@@ -0,0 +1,34 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestCallerFilters(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Std.DenyCallers("github.com/miyaizu/golog_test")
+	golog.Info("denied by package")
+
+	golog.Std.ResetCallerFilters()
+	golog.Std.DenyCallers(".../*_test.go")
+	golog.Info("denied by file")
+
+	golog.Std.ResetCallerFilters()
+	golog.Std.AllowCallers("github.com/other/...")
+	golog.Info("not allowed")
+
+	golog.Std.AllowCallers("github.com/miyaizu/...")
+	golog.Info("allowed")
+
+	if got, want := sink.messages(), []string{"allowed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package golog
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// fingerprint identifies entries logged from the same call site with the
+// same format string, independent of the formatted arguments.
+func fingerprint(entry *Entry) string {
+	h := fnv.New64a()
+	h.Write([]byte(entry.template))
+	h.Write([]byte{0})
+	h.Write([]byte(entry.Caller))
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// attachFingerprint adds a "fingerprint" field unless entry has one already,
+// as an escalated entry does: it is a copy of an entry that was emitted.
+func (entry *Entry) attachFingerprint() {
+	for _, field := range entry.Fields {
+		if field.Key == "fingerprint" {
+			return
+		}
+	}
+
+	entry.Fields = append(entry.Fields, Field{Key: "fingerprint", Value: fingerprint(entry)})
+}
@@ -0,0 +1,71 @@
+package golog_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestFingerprints(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetFingerprints(true)
+
+	for i := 0; i < 2; i++ {
+		golog.Error("query failed: %d", i)
+	}
+	golog.Error("query failed: %d", 2)
+	golog.Warn("warning")
+
+	fp := func(entry golog.Entry) string {
+		for _, field := range entry.Fields {
+			if field.Key == "fingerprint" {
+				return field.Value.(string)
+			}
+		}
+		return ""
+	}
+
+	entries := sink.entries
+	if fp(entries[0]) == "" || fp(entries[0]) != fp(entries[1]) {
+		t.Errorf("same call site should share a fingerprint: %q, %q", fp(entries[0]), fp(entries[1]))
+	}
+	if fp(entries[2]) == fp(entries[0]) {
+		t.Error("different call sites should not share a fingerprint")
+	}
+	if fp(entries[3]) != "" {
+		t.Error("warnings should not be fingerprinted")
+	}
+}
+
+func TestFingerprintEscalated(t *testing.T) {
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.SetLevelOutput(golog.LError, &bytes.Buffer{})
+	gl.SetLevelOutput(golog.LPanic, &bytes.Buffer{})
+	sink := &memorySink{}
+	gl.AddSink(sink)
+	gl.SetFingerprints(true)
+	gl.AddEscalationRule(golog.EscalationRule{Level: golog.LError, To: golog.LPanic, Threshold: 1, Window: time.Minute})
+
+	gl.Error("query failed")
+
+	for _, entry := range sink.entries {
+		var n int
+		for _, field := range entry.Fields {
+			if field.Key == "fingerprint" {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Errorf("%s entry has %d fingerprints", entry.Level, n)
+		}
+	}
+	if len(sink.entries) != 2 {
+		t.Errorf("expected the entry and its escalation, got %d entries", len(sink.entries))
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package golog
+
+import (
+	"errors"
+	"os"
+)
+
+var errLockUnsupported = errors.New("golog: file locking is not supported on this platform")
+
+func lockFile(file *os.File) error {
+	return errLockUnsupported
+}
+
+func unlockFile(file *os.File) error {
+	return errLockUnsupported
+}
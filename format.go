@@ -0,0 +1,197 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Format selects how console lines are encoded.
+type Format uint8
+
+const (
+	// FormatText renders the header template followed by the message.
+	FormatText Format = iota
+
+	// FormatJSON renders each entry as a single-line JSON object with
+	// timestamp, level, caller, logger and message keys, followed by id and
+	// the fields, for log shippers that would otherwise parse the text header.
+	FormatJSON
+
+	// FormatGCP renders Cloud Logging structured JSON: severity, time,
+	// message, logging.googleapis.com/sourceLocation and the logger name as
+	// a label, followed by the fields, so lines written to stdout on GKE or
+	// Cloud Run keep their severity.
+	FormatGCP
+)
+
+func (gl *GoLog) SetFormat(format Format) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.Format = format
+	gl.publish()
+}
+
+// encode renders entry in a structured format; it returns false for
+// FormatText, whose header is up to the caller.
+func (format Format) encode(entry *Entry) (string, bool) {
+	return format.encodePrecision(entry, TimeDefault)
+}
+
+// encodePrecision is encode with timestamps of the given precision.
+func (format Format) encodePrecision(entry *Entry, precision TimePrecision) (string, bool) {
+	switch format {
+	case FormatJSON:
+		return formatJSON(entry, precision.rfc3339()), true
+	case FormatGCP:
+		return formatGCP(entry, precision.rfc3339()), true
+	}
+
+	return "", false
+}
+
+// jsonBaseKeys are written first, in this order; fields never overwrite them.
+var jsonBaseKeys = map[string]bool{
+	"timestamp": true,
+	"level":     true,
+	"caller":    true,
+	"logger":    true,
+	"prefix":    true,
+	"goroutine": true,
+	"message":   true,
+	"id":        true,
+}
+
+// formatJSON encodes entry as one JSON object. Keys keep a fixed order so
+// lines stay readable and diffable.
+func formatJSON(entry *Entry, layout string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "timestamp", entry.Time.Format(layout), true)
+	writeJSONPair(&buf, "level", trimLevel(entry.Level), false)
+	writeJSONPair(&buf, "caller", entry.Caller, false)
+	if entry.Logger != "" {
+		writeJSONPair(&buf, "logger", entry.Logger, false)
+	}
+	if entry.Prefix != "" {
+		writeJSONPair(&buf, "prefix", entry.Prefix, false)
+	}
+	if entry.Goroutine != 0 {
+		writeJSONPair(&buf, "goroutine", entry.Goroutine, false)
+	}
+	writeJSONPair(&buf, "message", entry.Message, false)
+	if entry.ID != "" {
+		writeJSONPair(&buf, "id", entry.ID, false)
+	}
+	for _, field := range entry.Fields {
+		if jsonBaseKeys[field.Key] {
+			continue
+		}
+		writeJSONPair(&buf, field.Key, field.Value, false)
+	}
+	buf.WriteByte('}')
+
+	return buf.String()
+}
+
+func writeJSONPair(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+
+	k, _ := json.Marshal(key)
+	buf.Write(k)
+	buf.WriteByte(':')
+
+	// Errors usually have no exported fields and would encode as {}.
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(v)
+}
+
+// gcpBaseKeys are written by formatGCP itself; fields never overwrite them.
+var gcpBaseKeys = map[string]bool{
+	"severity":                              true,
+	"time":                                  true,
+	"message":                               true,
+	"logging.googleapis.com/sourceLocation": true,
+	"logging.googleapis.com/insertId":       true,
+	"logging.googleapis.com/labels":         true,
+	"prefix":                                true,
+	"goroutine":                             true,
+}
+
+// formatGCP encodes entry as a Cloud Logging structured log line. The entry
+// ID becomes the insertId so retried writes are deduplicated, and the
+// logger name a "logger" label to filter on.
+func formatGCP(entry *Entry, layout string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "severity", gcpSeverity(entry.Level), true)
+	writeJSONPair(&buf, "time", entry.Time.Format(layout), false)
+	writeJSONPair(&buf, "message", entry.Message, false)
+	if entry.File != "" {
+		writeJSONPair(&buf, "logging.googleapis.com/sourceLocation", map[string]string{
+			"file":     entry.File,
+			"line":     strconv.Itoa(entry.Line),
+			"function": entry.Function,
+		}, false)
+	}
+	if entry.ID != "" {
+		writeJSONPair(&buf, "logging.googleapis.com/insertId", entry.ID, false)
+	}
+	if entry.Logger != "" {
+		writeJSONPair(&buf, "logging.googleapis.com/labels", map[string]string{"logger": entry.Logger}, false)
+	}
+	if entry.Prefix != "" {
+		writeJSONPair(&buf, "prefix", entry.Prefix, false)
+	}
+	if entry.Goroutine != 0 {
+		writeJSONPair(&buf, "goroutine", entry.Goroutine, false)
+	}
+	for _, field := range entry.Fields {
+		if gcpBaseKeys[field.Key] {
+			continue
+		}
+		writeJSONPair(&buf, field.Key, field.Value, false)
+	}
+	buf.WriteByte('}')
+
+	return buf.String()
+}
+
+// gcpSyslogSeverities are the Cloud Logging names of the syslog severities.
+var gcpSyslogSeverities = [8]string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
+// gcpSeverity maps a level to a Cloud Logging LogSeverity.
+func gcpSeverity(level Level) string {
+	switch level {
+	case LTrace, LDebug:
+		return "DEBUG"
+	case LInfo:
+		return "INFO"
+	case LNotice:
+		return "NOTICE"
+	case LWarning:
+		return "WARNING"
+	case LError:
+		return "ERROR"
+	case LDPanic:
+		return "CRITICAL"
+	case LPanic, LFatal:
+		return "ALERT"
+	}
+	if custom, ok := lookupLevel(level); ok {
+		return gcpSyslogSeverities[custom.syslog]
+	}
+
+	return "DEFAULT"
+}
@@ -0,0 +1,97 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestFormatJSON(t *testing.T) {
+	if os.Getenv("GOLOG_FORMAT_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{Colorize: true, MinLevel: golog.LInfo, Format: golog.FormatJSON})
+		golog.SetOutput(golog.OStdout)
+		golog.GetLogger("storage").Code("E100").Warn("disk %d%% full", 93)
+		golog.Error("failed: %v", errors.New("boom"))
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFormatJSON$")
+	cmd.Env = append(os.Environ(), "GOLOG_FORMAT_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(out), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected 2 lines, got %q", out)
+	}
+	if !strings.HasPrefix(lines[0], `{"timestamp":"`) {
+		t.Errorf("expected timestamp first, got %q", lines[0])
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("%v: %q", err, lines[0])
+	}
+	if record["level"] != "warn" || record["message"] != "disk 93% full" || record["code"] != "E100" {
+		t.Errorf("unexpected record %v", record)
+	}
+	if !strings.HasPrefix(record["caller"].(string), "format_test.go:") {
+		t.Errorf("unexpected caller %v", record["caller"])
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("%v: %q", err, lines[1])
+	}
+	if record["level"] != "error" || record["message"] != "failed: boom" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestFormatGCP(t *testing.T) {
+	if os.Getenv("GOLOG_FORMAT_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo, Format: golog.FormatGCP})
+		golog.SetOutput(golog.OStdout)
+		golog.GetLogger("storage").Code("E100").Warn("disk %d%% full", 93)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFormatGCP$")
+	cmd.Env = append(os.Environ(), "GOLOG_FORMAT_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := strings.Split(string(out), "\n")[0]
+	if !strings.HasPrefix(line, `{"severity":"WARNING","time":"`) {
+		t.Errorf("expected severity first, got %q", line)
+	}
+
+	var record struct {
+		Message        string `json:"message"`
+		Code           string `json:"code"`
+		SourceLocation struct {
+			File     string `json:"file"`
+			Line     string `json:"line"`
+			Function string `json:"function"`
+		} `json:"logging.googleapis.com/sourceLocation"`
+		Labels map[string]string `json:"logging.googleapis.com/labels"`
+	}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("%v: %q", err, line)
+	}
+	if record.Message != "disk 93% full" || record.Code != "E100" || record.Labels["logger"] != "storage" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if !strings.HasSuffix(record.SourceLocation.File, "format_test.go") || record.SourceLocation.Line == "" ||
+		!strings.HasSuffix(record.SourceLocation.Function, "TestFormatGCP") {
+		t.Errorf("unexpected source location %+v", record.SourceLocation)
+	}
+}
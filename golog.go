@@ -3,85 +3,196 @@ package golog
 import (
 	"bytes"
 	"fmt"
-	"html/template"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	texttemplate "text/template"
 	"time"
 
 	"github.com/fatih/color"
 )
 
+// GoLog is a logger. Its exported fields show the current settings; change
+// them only through the Set methods, which publish the change to the write
+// path atomically.
 type GoLog struct {
 	MinLevel     Level
 	DefaultLevel Level
-	Colorize     bool
-	Header       *template.Template
-	UserHeader   string
 
-	mu  sync.Mutex
-	out io.Writer
+	// SampledMinLevel lets entries logged with a context whose trace is
+	// sampled through down to this level, even below MinLevel.
+	SampledMinLevel Level
+
+	// Colorize colors console lines when the output is a terminal and
+	// NO_COLOR is unset; see SetForceColor.
+	Colorize   bool
+	ColorMode  ColorMode
+	Format     Format
+	Header     *texttemplate.Template
+	UserHeader string
+	EntryIDs   EntryIDKind
+	forceColor bool
+	terminals  terminals
+
+	CallerFormat   CallerFormat
+	CallerRoot     string
+	callerLink     *texttemplate.Template
+	hyperlinks     bool
+	callerFunction bool
+	noCaller       bool
+
+	Development bool
+	exitCode    int
+	exitFunc    func(code int)
+	exitHooks   []func(entry Entry)
+
+	// Fingerprints adds a "fingerprint" field to LError and LPanic entries,
+	// a hash of the format string and caller that groups recurring errors.
+	Fingerprints bool
+
+	name        string
+	parent      *GoLog
+	children    []*GoLog
+	mu          sync.Mutex
+	cfg         atomic.Value
+	out         io.Writer
+	errOut      io.Writer
+	sinks       []sinkEntry
+	escalations []*escalation
+	profiles    []levelProfile
+	budget      *budget
+	digest      *digest
+	limiter     *rateLimiter
+	repeats     *repeatFilter
+	async       *asyncWriter
+	samplers    map[Level]*sampler
+	callers     *callerFilter
+	fileLevels  *fileLevels
+	rand        *Rand
+	icons       IconSet
+	levelLabels map[Level]string
+	levelColors Theme
+	wrap        int
+	status      *statusLine
+	levelOuts   map[Level]io.Writer
+	buffer      *bufferedWriter
+	pprofLabels bool
+	clock       func() time.Time
+	timeFormat  string
+	utc         bool
+	precision   TimePrecision
+	app         string
+	version     string
+	goroutines  bool
+	prefix      string
+	multiline   MultilineMode
+	hexLimit    int
 }
 
 type GoLogOption struct {
 	Colorize bool
 	MinLevel Level
+	Format   Format
+
+	// Theme colors the levels on the console; DefaultTheme when nil.
+	Theme Theme
+
+	// Development makes DPanic panic instead of only logging.
+	Development bool
 }
 
+// HeaderDefaultParam is the data of header templates; see
+// SetHeaderTemplate.
 type HeaderDefaultParam struct {
-	Level  string
-	Date   string
-	Caller string
+	// Level is the level as displayed, with its icon and colors; LevelName
+	// is the plain name, e.g. "warn".
+	Level     string
+	LevelName string
+	Icon      string
+	Date      string
+	Caller    string
+	Function  string
+	ID        string
+	Logger    string
+	Prefix    string
+	Goroutine uint64
+
+	PID      int
+	Hostname string
+
+	// App and Version are set with SetAppInfo.
+	App     string
+	Version string
 }
 
 type Output uint8
 type Level uint8
+type ColorMode uint8
 type colorFunc func(...interface{}) string
 
 const (
 	unknownOutput Output = iota
 	OStdout
 	OStderr
+
+	// OSplit writes entries below LWarning to stdout and the rest to
+	// stderr, as twelve-factor apps and container log collectors expect.
+	OSplit
+)
+
+const (
+	// ColorLevel colors only the level label and caller.
+	ColorLevel ColorMode = iota
+
+	// ColorLine colors the whole line in the level's color.
+	ColorLine
+
+	// ColorMessage colors only the message in the level's color.
+	ColorMessage
 )
 
+// levelStep spaces the built-in levels apart so that levels added with
+// RegisterLevel fit between them.
+const levelStep = 10
+
 const (
-	unknownLevel Level = iota
+	unknownLevel Level = iota * levelStep
 	LTrace
 	LDebug
 	LInfo
 	LNotice
 	LWarning
 	LError
+	LDPanic
 	LPanic
+	LFatal
 )
 
-var glstd *GoLog
-var glerr *GoLog
+var Std *GoLog
+var Err *GoLog
+var Split *GoLog
 var glcur *GoLog
 var currentOutput Output = OStderr
 
 func (level Level) Color() colorFunc {
-	switch level {
-	case LTrace:
-		return color.New(color.FgWhite).SprintFunc()
-	case LDebug:
-		return color.New(color.FgBlue).SprintFunc()
-	case LInfo:
-		return color.New(color.FgGreen).SprintFunc()
-	case LNotice:
-		return color.New(color.FgMagenta).SprintFunc()
-	case LWarning:
-		return color.New(color.FgYellow).SprintFunc()
-	case LError:
-		return color.New(color.FgRed).SprintFunc()
-	case LPanic:
-		return color.New(color.FgHiWhite, color.BgRed).SprintFunc()
+	return levelColor(level).SprintFunc()
+}
+
+func levelColor(level Level) *color.Color {
+	if c := DefaultTheme[level]; c != nil {
+		return c
+	}
+	if custom, ok := lookupLevel(level); ok && custom.color != nil {
+		return custom.color
 	}
 
-	return color.New(color.FgWhite).SprintFunc()
+	return color.New(color.FgWhite)
 }
 
 func (level Level) String() string {
@@ -98,19 +209,51 @@ func (level Level) String() string {
 		return "  warn"
 	case LError:
 		return " error"
+	case LDPanic:
+		return "dpanic"
 	case LPanic:
 		return " panic"
+	case LFatal:
+		return " fatal"
+	}
+	if custom, ok := lookupLevel(level); ok {
+		return padText(-6, custom.name)
 	}
 
 	return "unknown"
 }
 
+// trimLevel is the level name without the padding used for alignment.
+func trimLevel(level Level) string {
+	return strings.TrimSpace(level.String())
+}
+
+// ParseLevel returns the level named s, e.g. "debug" or "WARN"; "warning"
+// is accepted for LWarning.
+func ParseLevel(s string) (Level, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "warning" {
+		return LWarning, nil
+	}
+	for _, level := range allLevels() {
+		if s == strings.ToLower(trimLevel(level)) {
+			return level, nil
+		}
+	}
+
+	return unknownLevel, fmt.Errorf("golog: unknown level %q", s)
+}
+
 func NewGoLog(output Output, option *GoLogOption) *GoLog {
 	gl := new(GoLog)
 
 	gl.Colorize = option.Colorize
 	gl.MinLevel = option.MinLevel
+	gl.Format = option.Format
+	gl.Development = option.Development
+	gl.levelColors = option.Theme
 	gl.DefaultLevel = LInfo
+	gl.exitCode = 1
 	gl.Header = nil
 	gl.UserHeader = ""
 
@@ -119,31 +262,48 @@ func NewGoLog(output Output, option *GoLogOption) *GoLog {
 		gl.out = os.Stdout
 	case OStderr:
 		gl.out = os.Stderr
+	case OSplit:
+		gl.out = os.Stdout
+		gl.errOut = os.Stderr
 	default:
 		log.Panic("Output is unknown")
 	}
+	gl.detectTerminals()
 
 	register(gl)
 
 	return gl
 }
 
+// SetupLogger creates Std, Err and Split with option. A nil option uses
+// colors and LDebug, overridden by the environment; see optionFromEnv.
 func SetupLogger(option *GoLogOption) {
+	output := currentOutput
 	if option == nil {
 		option = &GoLogOption{
 			Colorize: true,
 			MinLevel: LDebug,
 		}
+		output = optionFromEnv(option, output)
 	}
 
-	glstd = NewGoLog(OStdout, option)
-	glerr = NewGoLog(OStderr, option)
+	Std = NewGoLog(OStdout, option)
+	Err = NewGoLog(OStderr, option)
+	Split = NewGoLog(OSplit, option)
+	for _, gl := range []*GoLog{Std, Err, Split} {
+		gl.applyLevelPatterns()
+	}
 
-	SetOutput(currentOutput)
+	SetOutput(output)
+	resetLoggers()
 }
 
 func register(gl *GoLog) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
 	gl.setDefaultHeader()
+	gl.publish()
 }
 
 func SetOutput(output Output) {
@@ -152,25 +312,36 @@ func SetOutput(output Output) {
 		glcur = getStdLogger()
 	case OStderr:
 		glcur = getErrLogger()
+	case OSplit:
+		glcur = getSplitLogger()
 	default:
 		log.Panic("Output is unknown")
 	}
 
 	currentOutput = output
+	followCurrentLogger()
 }
 
 func GetCurrentOutput() Output {
 	return currentOutput
 }
 
-func (gl *GoLog) setDefaultHeader() {
-	tmplStr := "[{{.Level}}] {{.Date}} ({{.Caller}}): "
-	tmpl, err := template.New("GoLogHeaderTemplate").Parse(tmplStr)
+func newDefaultHeader() *texttemplate.Template {
+	return defaultHeader
+}
+
+func parseDefaultHeader() *texttemplate.Template {
+	tmplStr := "[{{.Level}}] {{.Date}} {{if .Prefix}}[{{.Prefix}}] {{end}}{{if .Logger}}{{.Logger}} {{end}}({{.Caller}}){{if .Goroutine}} #{{.Goroutine}}{{end}}{{if .ID}} [{{.ID}}]{{end}}: "
+	tmpl, err := texttemplate.New("GoLogHeaderTemplate").Parse(tmplStr)
 	if err != nil {
 		panic(err)
 	}
 
-	gl.Header = tmpl
+	return tmpl
+}
+
+func (gl *GoLog) setDefaultHeader() {
+	gl.Header = newDefaultHeader()
 }
 
 func (gl *GoLog) SetUserHeader(header string) {
@@ -178,6 +349,7 @@ func (gl *GoLog) SetUserHeader(header string) {
 	defer gl.mu.Unlock()
 
 	gl.UserHeader = header
+	gl.publish()
 }
 
 func (gl *GoLog) SetMinLevel(level Level) {
@@ -185,6 +357,7 @@ func (gl *GoLog) SetMinLevel(level Level) {
 	defer gl.mu.Unlock()
 
 	gl.MinLevel = level
+	gl.publish()
 }
 
 func (gl *GoLog) SetDefaultLevel(level Level) {
@@ -192,6 +365,31 @@ func (gl *GoLog) SetDefaultLevel(level Level) {
 	defer gl.mu.Unlock()
 
 	gl.DefaultLevel = level
+	gl.publish()
+}
+
+func (gl *GoLog) SetSampledMinLevel(level Level) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.SampledMinLevel = level
+	gl.publish()
+}
+
+func (gl *GoLog) SetFingerprints(enable bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.Fingerprints = enable
+	gl.publish()
+}
+
+func (gl *GoLog) SetDevelopment(development bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.Development = development
+	gl.publish()
 }
 
 func (gl *GoLog) SetColorize(colorize bool) {
@@ -199,78 +397,387 @@ func (gl *GoLog) SetColorize(colorize bool) {
 	defer gl.mu.Unlock()
 
 	gl.Colorize = colorize
+	gl.publish()
 }
 
-func (gl *GoLog) write(text string, level Level) {
-	if level >= gl.MinLevel {
-		gl.out.Write([]byte(text + "\n"))
+func (gl *GoLog) SetColorMode(mode ColorMode) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.ColorMode = mode
+	gl.publish()
+}
+
+// newEntry creates an entry logged by newEntry's caller's caller. It returns
+// nil if nothing would be written at level, so disabled calls skip the
+// caller lookup; DPanic and Panic entries are always created.
+func (gl *GoLog) newEntry(level Level, text string, args []interface{}) *Entry {
+	if level < LDPanic && !gl.Enabled(level) {
+		return nil
 	}
+
+	var pcs [1]uintptr
+	if !gl.config().noCaller {
+		runtime.Callers(3, pcs[:])
+	}
+
+	return gl.newEntryAt(pcs[0], level, text, args)
+}
+
+// newEntryAt creates an entry logged from the call site pc, for adapters
+// that receive the call site along with the record; 0 leaves it unknown.
+func (gl *GoLog) newEntryAt(pc uintptr, level Level, text string, args []interface{}) *Entry {
+	c := gl.config()
+	entry := &Entry{
+		Level:    level,
+		Time:     c.now(),
+		Logger:   c.name,
+		Prefix:   c.prefix,
+		template: text,
+		args:     args,
+		pending:  true,
+	}
+	setCallerPC(entry, pc)
+	c.formatCaller(entry)
+	if c.goroutineIDs {
+		entry.Goroutine = goroutineID()
+	}
+	if c.entryIDs != IDNone {
+		entry.ID = newEntryID(c.entryIDs, entry.Time)
+	}
+
+	return entry
 }
 
-func getDate() string {
-	return time.Now().Format("2006-01-02 15:04:05")
+// LogAt logs message as is, not as a format string, from the call site pc
+// (0 if unknown). It lets adapters for other logging APIs attribute entries
+// to their callers rather than to the adapter.
+func (gl *GoLog) LogAt(pc uintptr, level Level, message string, fields ...Field) {
+	if !gl.Enabled(level) {
+		return
+	}
+
+	entry := gl.newEntryAt(pc, level, "%s", []interface{}{message})
+	entry.Fields = fields
+	gl.write(entry)
 }
 
-func getCaller(logger *GoLog) string {
-	var caller string = "unknown"
+// Enabled reports whether an entry at level may be written by the console
+// or a sink. Entries it accepts can still be dropped by per-caller rules.
+func (gl *GoLog) Enabled(level Level) bool {
+	c := gl.config()
+	if c.fileLevels != nil || c.sampledMinLevel != unknownLevel {
+		// Decided per entry from its caller or context.
+		return true
+	}
 
-	_, sourceFileName, sourceFileLineNum, ok := runtime.Caller(4)
-	if ok {
-		if logger.Colorize {
-			caller = color.CyanString(
-				fmt.Sprintf("%s:%d", filepath.Base(sourceFileName), sourceFileLineNum))
-		} else {
-			caller = fmt.Sprintf("%s:%d", filepath.Base(sourceFileName), sourceFileLineNum)
-		}
+	minLevel := c.minLevelAt(c.now())
+
+	return level >= minLevel || c.sinksAdmit(level, minLevel) || c.escalates(level)
+}
+
+func (gl *GoLog) write(entry *Entry) {
+	if entry == nil {
+		return
+	}
+
+	c := gl.config()
+	if !c.callerAllowed(entry) {
+		return
+	}
+
+	gl.deliver(c, entry)
+}
+
+// deliver emits an entry that passed the caller filters at the level in
+// effect for it, followed by any escalation it triggers.
+func (gl *GoLog) deliver(c *config, entry *Entry) {
+	minLevel := c.minLevelFor(entry)
+	gl.emit(c, entry, minLevel)
+
+	if escalated := c.escalate(entry); escalated != nil {
+		gl.emit(c, escalated, minLevel)
+	}
+}
+
+func (gl *GoLog) emit(c *config, entry *Entry, minLevel Level) {
+	console := entry.Level >= minLevel
+	if !console && !c.sinksAdmit(entry.Level, minLevel) {
+		return
+	}
+	if !c.sampled(entry) {
+		return
+	}
+	entry.render()
+
+	if c.fingerprints && entry.Level >= LError {
+		entry.attachFingerprint()
+	}
+
+	if c.async.enqueue(gl, c, entry, minLevel, console) {
+		return
 	}
+	gl.output(c, entry, minLevel, console)
+}
+
+// output writes a rendered entry to the console, unless it is not meant for
+// it, and to the sinks. The digest, repeat, rate and budget filters see each
+// entry once, including those meant for sinks only, and drop it from both.
+func (gl *GoLog) output(c *config, entry *Entry, minLevel Level, console bool) {
+	if !gl.digestAdmit(c.digest, entry) || !gl.repeatAdmit(c.repeats, entry) ||
+		!gl.rateAdmit(c.limiter, entry) || !gl.spendBudget(c.budget, entry) {
+		return
+	}
+
+	if console {
+		buf := getLineBuffer()
+		*buf = append(appendFormattedText(*buf, entry, gl, c), '\n')
+		gl.writeLine(c, entry.Level, *buf)
+		putLineBuffer(buf)
+	}
+	c.writeSinks(entry, minLevel)
+}
 
-	return caller
+// writeInternal writes an entry generated by the logger itself, bypassing
+// levels, filters and the budget.
+func (gl *GoLog) writeInternal(level Level, message string) {
+	c := gl.config()
+	entry := &Entry{Level: level, Time: c.now(), Logger: c.name, Prefix: c.prefix, Caller: "golog", Message: message}
+	buf := getLineBuffer()
+	*buf = append(appendFormattedText(*buf, entry, gl, c), '\n')
+	gl.writeLine(c, level, *buf)
+	putLineBuffer(buf)
+	c.writeSinks(entry, unknownLevel)
 }
 
-func getHeader(logger *GoLog, level Level) string {
+const dateLayout = "2006-01-02 15:04:05"
+
+func setCaller(entry *Entry, skip int) {
+	var pcs [1]uintptr
+	runtime.Callers(skip+1, pcs[:])
+	setCallerPC(entry, pcs[0])
+}
+
+func setCallerPC(entry *Entry, pc uintptr) {
+	entry.Caller = "unknown"
+	if pc == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	setCallerFrame(entry, frame)
+}
+
+func setCallerFrame(entry *Entry, frame runtime.Frame) {
+	if frame.File != "" {
+		entry.Caller = filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		entry.File = frame.File
+		entry.Line = frame.Line
+		entry.Function = frame.Function
+	}
+}
+
+func getHeader(c *config, entry *Entry, style lineStyle) string {
 	var header string
-	if logger.UserHeader != "" {
-		header = logger.UserHeader
+	if c.userHeader != "" {
+		header = c.userHeader
 	} else {
-		var levelStr string = level.String()
-		if logger.Colorize {
-			levelStr = level.Color()(levelStr)
-		}
+		header = renderHeader(c.header, entry, style)
+	}
 
-		hp := HeaderDefaultParam{
-			Level:  levelStr,
-			Date:   getDate(),
-			Caller: getCaller(logger),
-		}
+	return header
+}
 
-		var buf bytes.Buffer
-		logger.Header.Execute(&buf, hp)
+// lineStyle decorates a rendered line; the zero value is plain text.
+type lineStyle struct {
+	colorize bool
+	mode     ColorMode
+	icons    IconSet
+	labels   map[Level]string
+	colors   Theme
+
+	// force colors even when color.NoColor is set because stdout is not a
+	// terminal: the console decides per output instead, and sinks that
+	// explicitly ask for colors always want them.
+	force bool
+
+	// link turns the caller into an OSC 8 terminal hyperlink.
+	link string
+
+	// timeFormat lays out the header date, dateLayout with precision when
+	// empty, in UTC if utc is set.
+	timeFormat string
+	utc        bool
+	precision  TimePrecision
+
+	// app and version fill the header template's App and Version.
+	app     string
+	version string
+}
+
+func (c *config) lineStyle(entry *Entry) lineStyle {
+	return lineStyle{
+		colorize:   c.colorize && (c.forceColor || c.terminals.at(entry.Level)),
+		force:      true,
+		mode:       c.colorMode,
+		icons:      c.icons,
+		labels:     c.levelLabels,
+		colors:     c.levelColors,
+		link:       c.callerLinkURL(entry),
+		timeFormat: c.timeFormat,
+		utc:        c.utc,
+		precision:  c.timePrecision,
+		app:        c.app,
+		version:    c.version,
+	}
+}
 
-		header = buf.String()
+// date returns t as the header shows it and the layout to format it with.
+func (style lineStyle) date(t time.Time) (time.Time, string) {
+	if style.utc {
+		t = t.UTC()
+	}
+	if style.timeFormat == "" {
+		return t, dateLayout + style.precision.fraction()
 	}
 
-	return header
+	return t, style.timeFormat
+}
+
+func (style lineStyle) paint(c *color.Color, s string) string {
+	if style.force {
+		// Theme colors are shared, so enable a copy.
+		forced := *c
+		c = &forced
+		c.EnableColor()
+	}
+
+	return c.Sprint(s)
+}
+
+func renderHeader(tmpl *texttemplate.Template, entry *Entry, style lineStyle) string {
+	return string(appendHeader(nil, tmpl, entry, style))
+}
+
+// appendHeader appends the header rendered from tmpl. The default header is
+// formatted directly, which spares the template's reflection and
+// allocations on every line.
+func appendHeader(dst []byte, tmpl *texttemplate.Template, entry *Entry, style lineStyle) []byte {
+	var levelStr string = style.label(entry.Level)
+	var callerStr string = entry.Caller
+	icon := style.icons[entry.Level]
+	if icon != "" {
+		levelStr = icon
+	}
+	if style.colorize && style.mode == ColorLevel {
+		levelStr = style.paint(style.color(entry.Level), levelStr)
+		callerStr = style.paint(color.New(color.FgCyan), callerStr)
+	}
+
+	t, layout := style.date(entry.Time)
+	if tmpl == defaultHeader && style.link == "" {
+		return appendDefaultHeader(dst, levelStr, t, layout, entry.Prefix, entry.Logger, callerStr, entry.Goroutine, entry.ID)
+	}
+
+	hp := HeaderDefaultParam{
+		Level:     levelStr,
+		LevelName: style.name(entry.Level),
+		Icon:      icon,
+		Date:      t.Format(layout),
+		Caller:    callerStr,
+		Function:  entry.Function,
+		ID:        entry.ID,
+		Logger:    entry.Logger,
+		Prefix:    entry.Prefix,
+		Goroutine: entry.Goroutine,
+		PID:       processID,
+		Hostname:  hostname,
+		App:       style.app,
+		Version:   style.version,
+	}
+
+	var buf bytes.Buffer
+	tmpl.Execute(&buf, hp)
+
+	if style.link != "" {
+		return append(dst, strings.Replace(buf.String(), callerStr, hyperlink(style.link, callerStr), 1)...)
+	}
+
+	return append(dst, buf.Bytes()...)
+}
+
+// appendFormattedText appends the console line for entry, without the
+// trailing newline.
+func appendFormattedText(dst []byte, entry *Entry, logger *GoLog, c *config) []byte {
+	if text, ok := c.format.encodePrecision(entry, c.timePrecision); ok {
+		return append(dst, text...)
+	}
+
+	style := c.lineStyle(entry)
+	message := entry.textMessage()
+	stack := entry.stackSuffix()
+	multiline := c.multiline != MultilineKeep && c.wrap == 0 &&
+		(stack != "" || strings.ContainsAny(message, "\r\n"))
+
+	if c.wrap != 0 || multiline || (style.colorize && style.mode != ColorLevel) {
+		header := getHeader(c, entry, style)
+		if c.wrap != 0 {
+			message = wrapMessage(header, message, c.wrapWidth(logger.out))
+		} else if multiline {
+			message, stack = continueLines(c.multiline, header, message+stack), ""
+		}
+		dst = append(dst, formatLine(header, message, entry.Level, style)...)
+	} else {
+		// The header and message are simply joined; see formatLine.
+		if c.userHeader != "" {
+			dst = append(dst, c.userHeader...)
+		} else {
+			dst = appendHeader(dst, c.header, entry, style)
+		}
+		dst = append(dst, message...)
+	}
+
+	return append(dst, stack...)
 }
 
-func getFormattedText(text string, logger *GoLog, level Level) (string, Level) {
-	header := getHeader(logger, level)
-	return header + text, level
+// formatLine joins header and message, coloring them as style.mode asks.
+func formatLine(header, message string, level Level, style lineStyle) string {
+	if !style.colorize {
+		return header + message
+	}
+
+	switch style.mode {
+	case ColorLine:
+		return style.paint(style.color(level), header+message)
+	case ColorMessage:
+		return header + style.paint(style.color(level), message)
+	}
+
+	return header + message
 }
 
 func getStdLogger() *GoLog {
-	if glstd == nil {
+	if Std == nil {
 		log.Panic("The logger object is not initialized. Please call SetupLogger().")
 	}
 
-	return glstd
+	return Std
 }
 
 func getErrLogger() *GoLog {
-	if glstd == nil {
+	if Err == nil {
 		log.Panic("The logger object is not initialized. Please call SetupLogger().")
 	}
 
-	return glerr
+	return Err
+}
+
+func getSplitLogger() *GoLog {
+	if Split == nil {
+		log.Panic("The logger object is not initialized. Please call SetupLogger().")
+	}
+
+	return Split
 }
 
 func getCurrentLogger() *GoLog {
@@ -282,46 +789,122 @@ func getCurrentLogger() *GoLog {
 }
 
 func sprintf(text string, args []interface{}) string {
+	if len(args) == 0 && strings.IndexByte(text, '%') < 0 {
+		return text
+	}
+
 	return fmt.Sprintf(text, args...)
 }
 
+func (gl *GoLog) Log(text string, args ...interface{}) {
+	gl.write(gl.newEntry(gl.config().defaultLevel, text, args))
+}
+
+func (gl *GoLog) Trace(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LTrace, text, args))
+}
+
+func (gl *GoLog) Debug(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LDebug, text, args))
+}
+
+func (gl *GoLog) Info(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LInfo, text, args))
+}
+
+func (gl *GoLog) Notice(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LNotice, text, args))
+}
+
+func (gl *GoLog) Warn(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LWarning, text, args))
+}
+
+func (gl *GoLog) Error(text string, args ...interface{}) {
+	gl.write(gl.newEntry(LError, text, args))
+}
+
 func Log(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, logger.DefaultLevel))
+	logger.write(logger.newEntry(logger.config().defaultLevel, text, args))
 }
 
 func Trace(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LTrace))
+	logger.write(logger.newEntry(LTrace, text, args))
 }
 
 func Debug(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LDebug))
+	logger.write(logger.newEntry(LDebug, text, args))
 }
 
 func Info(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LInfo))
+	logger.write(logger.newEntry(LInfo, text, args))
 }
 
 func Notice(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LNotice))
+	logger.write(logger.newEntry(LNotice, text, args))
 }
 
 func Warn(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LWarning))
+	logger.write(logger.newEntry(LWarning, text, args))
 }
 
 func Error(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LError))
+	logger.write(logger.newEntry(LError, text, args))
+}
+
+// DPanic logs at LDPanic and, in development configuration, then panics.
+// Use it for conditions that should never happen.
+func (gl *GoLog) DPanic(text string, args ...interface{}) {
+	entry := gl.newEntry(LDPanic, text, args)
+	gl.write(entry)
+	gl.dpanic(entry)
+}
+
+// Panic logs at LPanic, runs the panic and exit hooks and then panics with
+// the message, so deferred calls run and recover works as for any panic.
+// Use Fatal to exit the process instead.
+func (gl *GoLog) Panic(text string, args ...interface{}) {
+	entry := gl.newEntry(LPanic, text, args)
+	gl.write(entry)
+	gl.panicEntry(entry)
+}
+
+// Fatal logs at LFatal, runs the panic and exit hooks, flushes gl's buffer,
+// async queue and sinks, and exits with gl's exit code, 1 unless changed
+// with SetExitCode, after closing gl. Deferred calls do not run.
+func (gl *GoLog) Fatal(text string, args ...interface{}) {
+	entry := gl.newEntry(LFatal, text, args)
+	gl.write(entry)
+	gl.fatal(entry)
+}
+
+// DPanic logs through the current logger; see GoLog.DPanic.
+func DPanic(text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LDPanic, text, args)
+	logger.write(entry)
+	logger.dpanic(entry)
 }
 
+// Panic logs through the current logger; see GoLog.Panic.
 func Panic(text string, args ...interface{}) {
 	logger := getCurrentLogger()
-	logger.write(getFormattedText(sprintf(text, args), logger, LPanic))
-	os.Exit(-1)
+	entry := logger.newEntry(LPanic, text, args)
+	logger.write(entry)
+	logger.panicEntry(entry)
+}
+
+// Fatal logs through the current logger; see GoLog.Fatal.
+func Fatal(text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	entry := logger.newEntry(LFatal, text, args)
+	logger.write(entry)
+	logger.fatal(entry)
 }
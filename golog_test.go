@@ -1,7 +1,13 @@
 package golog_test
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/miyaizu/golog"
 )
@@ -30,3 +36,128 @@ func TestGoLog(t *testing.T) {
 	golog.Warn("test")
 	golog.Error("test")
 }
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []golog.Entry
+}
+
+func (s *memorySink) Write(entry *golog.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, *entry)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func (s *memorySink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []string
+	for _, entry := range s.entries {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+func TestDPanic(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.DPanic("production")
+	if len(sink.entries) != 1 || sink.entries[0].Level != golog.LDPanic {
+		t.Fatalf("expected one dpanic entry, got %+v", sink.entries)
+	}
+
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo, Development: true})
+	golog.SetOutput(golog.OStdout)
+
+	defer func() {
+		if r := recover(); r != "development" {
+			t.Errorf("expected a panic in development, got %v", r)
+		}
+	}()
+	golog.DPanic("development")
+}
+
+func TestDisabledLevelIsFree(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	if n := testing.AllocsPerRun(100, func() { golog.Debug("skipped") }); n != 0 {
+		t.Errorf("expected no allocations below MinLevel, got %v", n)
+	}
+
+	// Escalation rules count entries below MinLevel.
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.AddEscalationRule(golog.EscalationRule{Level: golog.LDebug, Threshold: 2, Window: time.Minute, To: golog.LWarning})
+	for i := 0; i < 2; i++ {
+		golog.Debug("flapping")
+	}
+	if len(sink.entries) != 1 || sink.entries[0].Level != golog.LWarning {
+		t.Errorf("expected an escalated entry, got %+v", sink.entries)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	deferred := false
+	func() {
+		defer func() {
+			if r := recover(); r != "boom 1" {
+				t.Errorf("expected to recover the message, got %v", r)
+			}
+		}()
+		defer func() { deferred = true }()
+
+		golog.Panic("boom %d", 1)
+	}()
+
+	if !deferred {
+		t.Error("deferred calls did not run")
+	}
+	if len(sink.entries) != 1 || sink.entries[0].Level != golog.LPanic {
+		t.Errorf("expected one panic entry, got %+v", sink.entries)
+	}
+}
+
+func TestFatal(t *testing.T) {
+	if os.Getenv("GOLOG_FATAL_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetBuffer(&golog.BufferOption{FlushLevel: golog.LFatal + 1})
+		golog.Std.SetExitCode(3)
+		defer fmt.Println("deferred")
+		golog.Fatal("cannot continue")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatal$")
+	cmd.Env = append(os.Environ(), "GOLOG_FATAL_TEST=1")
+	out, err := cmd.Output()
+
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Fatalf("expected exit code 3, got %v", err)
+	}
+	if !strings.Contains(string(out), "[ fatal]") || !strings.Contains(string(out), "cannot continue") {
+		t.Errorf("fatal entry not written out: %q", out)
+	}
+	if strings.Contains(string(out), "deferred") {
+		t.Error("deferred calls ran")
+	}
+}
@@ -0,0 +1,94 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const goroutineDumpChunk = 32 << 10
+
+// DumpGoroutinesOnSignal writes a full goroutine dump at LNotice to the
+// logger's output and sinks whenever one of sigs (SIGQUIT by default)
+// arrives, so hang diagnostics reach centralized logs. Large dumps are split
+// into several entries at goroutine boundaries. Catching SIGQUIT replaces
+// the runtime's own dump-and-exit; call the returned function to restore it.
+func (gl *GoLog) DumpGoroutinesOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGQUIT}
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		for {
+			select {
+			case <-ch:
+				gl.DumpGoroutines()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// DumpGoroutines writes the stacks of all goroutines at LNotice.
+func (gl *GoLog) DumpGoroutines() {
+	chunks := chunkStacks(string(goroutineStacks()), goroutineDumpChunk)
+	for i, chunk := range chunks {
+		gl.writeInternal(LNotice, fmt.Sprintf("goroutine dump (%d/%d):\n%s", i+1, len(chunks), chunk))
+	}
+}
+
+func goroutineStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// chunkStacks groups goroutine stacks into chunks of at most limit bytes;
+// a single goroutine larger than limit is split mid-stack.
+func chunkStacks(stacks string, limit int) []string {
+	var chunks []string
+	var chunk strings.Builder
+
+	flush := func() {
+		if chunk.Len() > 0 {
+			chunks = append(chunks, strings.TrimRight(chunk.String(), "\n"))
+			chunk.Reset()
+		}
+	}
+
+	for _, stack := range strings.SplitAfter(strings.TrimSpace(stacks), "\n\n") {
+		if chunk.Len()+len(stack) > limit {
+			flush()
+		}
+		for len(stack) > limit {
+			chunks = append(chunks, stack[:limit])
+			stack = stack[limit:]
+		}
+		chunk.WriteString(stack)
+	}
+	flush()
+
+	return chunks
+}
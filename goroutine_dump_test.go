@@ -0,0 +1,42 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package golog_test
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestDumpGoroutinesOnSignal(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LError})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	stop := golog.Std.DumpGoroutinesOnSignal(syscall.SIGUSR1)
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.messages()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	messages := sink.messages()
+	if len(messages) == 0 {
+		t.Fatal("no goroutine dump was written")
+	}
+	if !strings.HasPrefix(messages[0], "goroutine dump (1/") ||
+		!strings.Contains(strings.Join(messages, "\n"), "TestDumpGoroutinesOnSignal") {
+		t.Errorf("unexpected dump %q", messages[0])
+	}
+	if sink.entries[0].Level != golog.LNotice {
+		t.Errorf("expected notice level, got %v", sink.entries[0].Level)
+	}
+}
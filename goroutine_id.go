@@ -0,0 +1,35 @@
+package golog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// SetGoroutineIDs records the ID of the logging goroutine in each entry,
+// shown as "#ID" after the caller in the default header, as Goroutine in
+// header templates and as "goroutine" in JSON and GCP lines, so
+// interleaved lines from a worker pool can be told apart. It costs a short
+// stack trace per entry.
+func (gl *GoLog) SetGoroutineIDs(enable bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.goroutines = enable
+	gl.publish()
+}
+
+// goroutineID parses the ID from the "goroutine 18 [running]:" line that
+// starts the current goroutine's stack trace; the runtime offers no other
+// way to get it.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+
+	return id
+}
@@ -0,0 +1,46 @@
+package golog_test
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestGoroutineIDs(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	out := &lockedBuffer{}
+	golog.Std.SetLevelOutput(golog.LInfo, out)
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetGoroutineIDs(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			golog.Info("worker")
+		}()
+	}
+	wg.Wait()
+
+	if len(sink.entries) != 2 || sink.entries[0].Goroutine == 0 || sink.entries[0].Goroutine == sink.entries[1].Goroutine {
+		t.Fatalf("unexpected goroutine ids %+v", sink.entries)
+	}
+	header := regexp.MustCompile(`^\[  info\] \S+ \S+ \(goroutine_id_test\.go:\d+\) #\d+: worker$`)
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if !header.MatchString(line) {
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+
+	tr := golog.NewTextReader(strings.NewReader(out.String()))
+	if entry, err := tr.Next(); err != nil || entry.Goroutine == 0 || entry.Message != "worker" {
+		t.Errorf("TextReader: %+v, %v", entry, err)
+	}
+}
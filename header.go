@@ -0,0 +1,86 @@
+package golog
+
+import (
+	"os"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+var processID = os.Getpid()
+var hostname, _ = os.Hostname()
+
+// headerFuncs are available in templates given to SetHeaderTemplate.
+var headerFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"pad":   padText,
+	"trunc": truncText,
+}
+
+// SetHeaderTemplate replaces the header with a template rendered from
+// HeaderDefaultParam, e.g.
+//
+//	gl.SetHeaderTemplate(`{{upper .LevelName | pad 5}} {{.Date}} {{.Hostname}}[{{.PID}}] {{trunc 20 .Caller}}: `)
+//
+// Besides the standard functions it offers upper and lower, pad N, which
+// pads to N characters on the right or, if N is negative, on the left, and
+// trunc N, which keeps the first N characters. Values are written as they
+// are, without escaping. An empty text restores the default header. A
+// header set with SetUserHeader takes precedence.
+func (gl *GoLog) SetHeaderTemplate(text string) error {
+	tmpl := defaultHeader
+	if text != "" {
+		var err error
+		if tmpl, err = template.New("GoLogHeaderTemplate").Funcs(headerFuncs).Parse(text); err != nil {
+			return err
+		}
+	}
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.Header = tmpl
+	gl.publish()
+
+	return nil
+}
+
+// SetAppInfo sets the App and Version of the header template.
+func (gl *GoLog) SetAppInfo(name, version string) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.app = name
+	gl.version = version
+	gl.publish()
+}
+
+func padText(width int, s string) string {
+	n := utf8.RuneCountInString(s)
+	if width < 0 {
+		if -width > n {
+			return strings.Repeat(" ", -width-n) + s
+		}
+		return s
+	}
+	if width > n {
+		return s + strings.Repeat(" ", width-n)
+	}
+
+	return s
+}
+
+func truncText(width int, s string) string {
+	if width < 0 {
+		return s
+	}
+	for i := range s {
+		if width == 0 {
+			return s[:i]
+		}
+		width--
+	}
+
+	return s
+}
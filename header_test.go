@@ -0,0 +1,62 @@
+package golog_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestHeaderTemplate(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelsOutput(&out, golog.LInfo, golog.LWarning)
+	golog.Std.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 5, 0, 0, time.Local) })
+	golog.Std.SetAppInfo("billing", "1.2.0")
+
+	err := golog.Std.SetHeaderTemplate(`{{upper .LevelName | pad 5}}|{{pad -3 "x"}}|{{.App}}@{{.Version}} {{.PID}} {{trunc 4 .Date}}: `)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golog.Info("custom")
+	golog.Warn("custom")
+
+	if err := golog.Std.SetHeaderTemplate(`{{.Missing`); err == nil {
+		t.Error("expected a parse error")
+	}
+	golog.Std.SetHeaderTemplate("")
+	golog.Info("default")
+
+	pid := os.Getpid()
+	want := fmt.Sprintf("INFO |  x|billing@1.2.0 %d 2018: custom\nWARN |  x|billing@1.2.0 %d 2018: custom\n", pid, pid)
+	got := out.String()
+	if !strings.HasPrefix(got, want) {
+		t.Fatalf("got %q, want prefix %q", got, want)
+	}
+	if rest := got[len(want):]; !strings.HasPrefix(rest, "[  info] 2018-07-01 09:05:00 (") {
+		t.Errorf("default header not restored: %q", rest)
+	}
+}
+
+func TestHeaderTemplateUnescaped(t *testing.T) {
+	var out bytes.Buffer
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.SetLevelOutput(golog.LInfo, &out)
+	gl.SetPrefix("a&b")
+	gl.SetAppInfo("R&D <tools>", "1.0+1")
+
+	if err := gl.SetHeaderTemplate(`{{.Prefix}} {{.App}}@{{.Version}}: `); err != nil {
+		t.Fatal(err)
+	}
+	gl.Info("it's plain text")
+
+	if got, want := out.String(), "a&b R&D <tools>@1.0+1: it's plain text\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package golog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// HexDump logs data at level in the canonical hexdump -C layout of offset,
+// hex bytes and ASCII, below a line with label and the length:
+//
+//	golog.Std.HexDump(golog.LDebug, "handshake", packet)
+//
+//	[ debug] 2024-05-01 12:00:00 (conn.go:42): handshake (12 bytes):
+//	00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64              |Hello, world|
+//
+// Only the first bytes up to the limit from SetHexDumpLimit are shown.
+// data is formatted once the entry passes the level filters, so it must not
+// be modified until the call returns.
+func (gl *GoLog) HexDump(level Level, label string, data []byte) {
+	gl.write(gl.newEntry(level, "%s", lazyArgs(func() string { return hexDump(label, data, gl.config().hexLimit) })))
+}
+
+func HexDump(level Level, label string, data []byte) {
+	logger := getCurrentLogger()
+	logger.write(logger.newEntry(level, "%s", lazyArgs(func() string { return hexDump(label, data, logger.config().hexLimit) })))
+}
+
+// SetHexDumpLimit truncates payloads logged with HexDump to their first n
+// bytes; 0 shows them in full.
+func (gl *GoLog) SetHexDumpLimit(n int) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.hexLimit = n
+	gl.publish()
+}
+
+func hexDump(label string, data []byte, limit int) string {
+	header := fmt.Sprintf("%s (%d bytes):", label, len(data))
+	if limit > 0 && len(data) > limit {
+		header = fmt.Sprintf("%s (%d bytes, first %d shown):", label, len(data), limit)
+		data = data[:limit]
+	}
+	if len(data) == 0 {
+		return header
+	}
+
+	return header + "\n" + strings.TrimSuffix(hex.Dump(data), "\n")
+}
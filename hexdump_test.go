@@ -0,0 +1,40 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestHexDump(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelsOutput(&out, golog.LDebug, golog.LInfo)
+
+	golog.Std.HexDump(golog.LDebug, "handshake", []byte("Hello, world"))
+	want := "): handshake (12 bytes):\n" +
+		"00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64              |Hello, world|\n"
+	if got := out.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want suffix %q", got, want)
+	}
+
+	out.Reset()
+	golog.Std.SetHexDumpLimit(4)
+	golog.HexDump(golog.LInfo, "frame", []byte{0x00, 0x01, 0x7f, 0x80, 0xff})
+	want = "): frame (5 bytes, first 4 shown):\n" +
+		"00000000  00 01 7f 80                                       |....|\n"
+	if got := out.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want suffix %q", got, want)
+	}
+
+	out.Reset()
+	golog.Std.SetMinLevel(golog.LInfo)
+	golog.Std.HexDump(golog.LDebug, "dropped", []byte{1})
+	if out.Len() != 0 {
+		t.Errorf("dump below the minimum level written: %q", out.String())
+	}
+}
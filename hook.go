@@ -0,0 +1,28 @@
+package golog
+
+// Hook is called with every entry a logger writes, after level filtering
+// and formatting, e.g. to count errors or page someone. The entry's Fields
+// are shared with other hooks and sinks and must not be modified.
+type Hook func(entry Entry) error
+
+// hookSink runs a hook as a sink; errors are reported like sink errors.
+type hookSink Hook
+
+func (h hookSink) Write(entry *Entry) error {
+	return h(*entry)
+}
+
+func (h hookSink) Close() error {
+	return nil
+}
+
+// AddHook registers a hook for the entries that pass MinLevel.
+func (gl *GoLog) AddHook(hook Hook) {
+	gl.AddSinkLevel(hookSink(hook), unknownLevel)
+}
+
+// AddHookLevel registers a hook for entries at or above level, independent
+// of MinLevel.
+func (gl *GoLog) AddHookLevel(hook Hook, level Level) {
+	gl.AddSinkLevel(hookSink(hook), level)
+}
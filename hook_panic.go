@@ -0,0 +1,65 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var panicHooksMu sync.Mutex
+var panicHooks []func(entry Entry)
+var panicHookTimeout = 5 * time.Second
+
+// RegisterPanicHook adds a function run before Fatal exits the process or
+// Panic panics, e.g. to release distributed locks or upload a crash bundle.
+// Hooks run in registration order and share one timeout.
+func RegisterPanicHook(hook func(entry Entry)) {
+	panicHooksMu.Lock()
+	defer panicHooksMu.Unlock()
+
+	panicHooks = append(panicHooks, hook)
+}
+
+func SetPanicHookTimeout(timeout time.Duration) {
+	panicHooksMu.Lock()
+	defer panicHooksMu.Unlock()
+
+	panicHookTimeout = timeout
+}
+
+// runPanicHooks runs the hooks, followed by more, and returns once they are
+// done or the timeout expires, whichever comes first. A panicking hook does
+// not stop the others.
+func runPanicHooks(entry *Entry, more ...func(entry Entry)) {
+	panicHooksMu.Lock()
+	hooks := append(panicHooks[:len(panicHooks):len(panicHooks)], more...)
+	timeout := panicHookTimeout
+	panicHooksMu.Unlock()
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for _, hook := range hooks {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						fmt.Fprintf(os.Stderr, "golog: panic hook failed: %v\n", r)
+					}
+				}()
+				hook(*entry)
+			}()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		fmt.Fprintf(os.Stderr, "golog: panic hooks did not finish within %s\n", timeout)
+	}
+}
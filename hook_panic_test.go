@@ -0,0 +1,43 @@
+package golog_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestPanicHook(t *testing.T) {
+	if os.Getenv("GOLOG_PANIC_HOOK_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetPanicHookTimeout(100 * time.Millisecond)
+		golog.RegisterPanicHook(func(entry golog.Entry) {
+			fmt.Printf("hook: %s\n", entry.Message)
+		})
+		golog.RegisterPanicHook(func(entry golog.Entry) {
+			panic("broken hook")
+		})
+		golog.RegisterPanicHook(func(entry golog.Entry) {
+			time.Sleep(time.Hour)
+		})
+		golog.Panic("boom")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestPanicHook$")
+	cmd.Env = append(os.Environ(), "GOLOG_PANIC_HOOK_TEST=1")
+	out, err := cmd.CombinedOutput()
+
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("expected the process to exit with an error, got %v", err)
+	}
+	for _, want := range []string{"hook: boom", "panic hook failed: broken hook", "did not finish within 100ms"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package golog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestHooks(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var all, errs []golog.Entry
+	golog.Std.AddHook(func(entry golog.Entry) error {
+		all = append(all, entry)
+		return nil
+	})
+	golog.Std.AddHookLevel(func(entry golog.Entry) error {
+		errs = append(errs, entry)
+		return errors.New("hook errors are reported, not fatal")
+	}, golog.LError)
+
+	golog.Debug("dropped")
+	golog.Std.With("user", 42).Info("login %s", "ok")
+	golog.Error("failed")
+
+	if len(all) != 2 || all[0].Message != "login ok" || all[0].Caller != "hook_test.go:25" || all[0].Fields[0].Value != 42 {
+		t.Errorf("unexpected entries %+v", all)
+	}
+	if len(errs) != 1 || errs[0].Level != golog.LError || errs[0].Message != "failed" {
+		t.Errorf("unexpected entries %+v", errs)
+	}
+}
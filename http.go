@@ -0,0 +1,29 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// doRequest sends req and reports whether a failure is worth retrying:
+// transport errors, throttling and server errors are, anything else is not.
+func doRequest(client *http.Client, req *http.Request, what string) (bool, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	retry := resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+
+	return retry, fmt.Errorf("golog: %s failed: %s: %s", what, resp.Status, body)
+}
@@ -0,0 +1,118 @@
+package golog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/mattn/go-isatty"
+)
+
+// CallerLinkParam is passed to the template set with SetCallerLink.
+type CallerLinkParam struct {
+	// File is the absolute path of the source file and Path the same file
+	// relative to the caller root (empty when outside of it).
+	File     string
+	Path     string
+	Line     int
+	Function string
+
+	// Commit is the VCS revision the binary was built from, if known.
+	Commit string
+}
+
+var buildCommitOnce sync.Once
+var buildCommit string
+
+// SetCallerLink renders callers on the console as OSC 8 hyperlinks to the
+// URL produced by urlTemplate, for example
+//
+//	https://github.com/user/repo/blob/{{.Commit}}/{{.Path}}#L{{.Line}}
+//
+// Links are only emitted when the output is a terminal known to support
+// them; FORCE_HYPERLINK=1 or 0 overrides the detection. An empty template
+// turns links off.
+func (gl *GoLog) SetCallerLink(urlTemplate string) error {
+	var tmpl *texttemplate.Template
+	if urlTemplate != "" {
+		var err error
+		if tmpl, err = texttemplate.New("GoLogCallerLinkTemplate").Parse(urlTemplate); err != nil {
+			return err
+		}
+	}
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.callerLink = tmpl
+	gl.hyperlinks = tmpl != nil && supportsHyperlinks(gl.out)
+	gl.publish()
+
+	return nil
+}
+
+func (c *config) callerLinkURL(entry *Entry) string {
+	if !c.hyperlinks || entry.File == "" {
+		return ""
+	}
+
+	param := CallerLinkParam{
+		File:     entry.File,
+		Line:     entry.Line,
+		Function: entry.Function,
+		Commit:   vcsCommit(),
+	}
+	param.Path, _ = c.relativePath(entry.File)
+
+	var buf bytes.Buffer
+	if err := c.callerLink.Execute(&buf, param); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+func vcsCommit() string {
+	buildCommitOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					buildCommit = setting.Value
+				}
+			}
+		}
+	})
+
+	return buildCommit
+}
+
+func supportsHyperlinks(w io.Writer) bool {
+	if force := os.Getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0"
+	}
+
+	file, ok := w.(*os.File)
+	if !ok || !isatty.IsTerminal(file.Fd()) || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty":
+		return true
+	}
+
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("DOMTERM") != "" {
+		return true
+	}
+
+	vte, _ := strconv.Atoi(os.Getenv("VTE_VERSION"))
+	return vte >= 5000
+}
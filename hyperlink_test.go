@@ -0,0 +1,32 @@
+package golog_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestCallerLink(t *testing.T) {
+	if os.Getenv("GOLOG_CALLER_LINK_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetCallerLink("https://example.com/{{.Path}}#L{{.Line}}")
+		golog.Info("linked")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCallerLink$")
+	cmd.Env = append(os.Environ(), "GOLOG_CALLER_LINK_TEST=1", "FORCE_HYPERLINK=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(\x1b]8;;https://example.com/hyperlink_test.go#L17\x1b\\hyperlink_test.go:17\x1b]8;;\x1b\\): linked"
+	if !strings.Contains(string(out), want) {
+		t.Errorf("output lacks hyperlink %q:\n%q", want, out)
+	}
+}
@@ -0,0 +1,42 @@
+package golog
+
+// IconSet maps levels to the icon shown in place of the level label on the
+// console. Levels missing from the set keep their label.
+type IconSet map[Level]string
+
+var EmojiIcons = IconSet{
+	LTrace:   "🔬",
+	LDebug:   "🐛",
+	LInfo:    "✅",
+	LNotice:  "📣",
+	LWarning: "⚠️",
+	LError:   "❌",
+	LDPanic:  "🚨",
+	LPanic:   "🔥",
+	LFatal:   "💀",
+}
+
+// NerdFontIcons needs a Nerd Font (https://www.nerdfonts.com) in the
+// terminal.
+var NerdFontIcons = IconSet{
+	LTrace:   "\uf002", // search
+	LDebug:   "\uf188", // bug
+	LInfo:    "\uf05a", // info-circle
+	LNotice:  "\uf0f3", // bell
+	LWarning: "\uf071", // warning
+	LError:   "\uf057", // times-circle
+	LDPanic:  "\uf1e2", // bomb
+	LPanic:   "\uf06d", // fire
+	LFatal:   "\uf54c", // skull
+}
+
+// SetIcons replaces level labels on the console with icons, e.g.
+// EmojiIcons. nil restores the labels. Headers also expose the icon as
+// {{.Icon}} for templates that want both.
+func (gl *GoLog) SetIcons(icons IconSet) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.icons = icons
+	gl.publish()
+}
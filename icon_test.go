@@ -0,0 +1,37 @@
+package golog_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestIcons(t *testing.T) {
+	if os.Getenv("GOLOG_ICONS_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetIcons(golog.EmojiIcons)
+		golog.Warn("careful")
+		golog.Std.SetIcons(golog.IconSet{golog.LError: "E"})
+		golog.Info("labelled")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestIcons$")
+	cmd.Env = append(os.Environ(), "GOLOG_ICONS_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(out), "\n")
+	if !strings.HasPrefix(lines[0], "[⚠️] ") || !strings.HasSuffix(lines[0], ": careful") {
+		t.Errorf("expected an icon, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "[  info] ") {
+		t.Errorf("expected the label for a level without icon, got %q", lines[1])
+	}
+}
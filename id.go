@@ -0,0 +1,89 @@
+package golog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type EntryIDKind uint8
+
+const (
+	IDNone EntryIDKind = iota
+	IDULID
+	IDUUID
+)
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// SetEntryIDs makes the logger attach a unique ID of the given kind to every
+// entry. The ID is shown in the default header and sent to sinks as "id".
+func (gl *GoLog) SetEntryIDs(kind EntryIDKind) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.EntryIDs = kind
+	gl.publish()
+}
+
+func newEntryID(kind EntryIDKind, t time.Time) string {
+	switch kind {
+	case IDULID:
+		return newULID(t)
+	case IDUUID:
+		return newUUID()
+	}
+
+	return ""
+}
+
+// newULID returns a ULID: 48 bits of millisecond time followed by 80 random
+// bits, in Crockford base32, so IDs sort by time.
+func newULID(t time.Time) string {
+	var b [16]byte
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	rand.Read(b[6:])
+
+	// 128 bits as 26 base32 digits; the first digit carries 3 bits.
+	var out [26]byte
+	out[0] = crockfordBase32[b[0]>>5]
+	pos := 1
+	acc := uint64(b[0] & 0x1f)
+	bits := uint(5)
+	for _, c := range b[1:] {
+		acc = acc<<8 | uint64(c)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockfordBase32[(acc>>bits)&0x1f]
+			pos++
+		}
+	}
+
+	return string(out[:])
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+
+	return string(out[:])
+}
@@ -0,0 +1,37 @@
+package golog_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestEntryIDs(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Info("no id")
+	golog.Std.SetEntryIDs(golog.IDULID)
+	golog.Info("ulid")
+	golog.Info("ulid")
+	golog.Std.SetEntryIDs(golog.IDUUID)
+	golog.Info("uuid")
+
+	ulid := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	entries := sink.entries
+	if entries[0].ID != "" {
+		t.Errorf("unexpected id %q", entries[0].ID)
+	}
+	if !ulid.MatchString(entries[1].ID) || entries[1].ID == entries[2].ID {
+		t.Errorf("bad ulids %q, %q", entries[1].ID, entries[2].ID)
+	}
+	if !uuid.MatchString(entries[3].ID) {
+		t.Errorf("bad uuid %q", entries[3].ID)
+	}
+}
@@ -0,0 +1,25 @@
+package golog
+
+import (
+	"net"
+	"os"
+	"syscall"
+)
+
+// sendJournalFD passes data to journald as a sealed-off temporary file, the
+// protocol's fallback for entries too large for one datagram.
+func sendJournalFD(conn *net.UnixConn, addr *net.UnixAddr, data []byte) error {
+	file, err := os.CreateTemp("/dev/shm", "golog-journal-")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+
+	_, _, err = conn.WriteMsgUnix(nil, syscall.UnixRights(int(file.Fd())), addr)
+	return err
+}
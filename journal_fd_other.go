@@ -0,0 +1,12 @@
+//go:build !linux
+
+package golog
+
+import (
+	"errors"
+	"net"
+)
+
+func sendJournalFD(conn *net.UnixConn, addr *net.UnixAddr, data []byte) error {
+	return errors.New("golog: entry is too large for the journal socket")
+}
@@ -0,0 +1,36 @@
+package golog
+
+// lazyArgs formats to the string fn returns, calling fn only once the entry
+// is known to be written.
+func lazyArgs(fn func() string) []interface{} {
+	return []interface{}{Lazy(func() interface{} { return fn() })}
+}
+
+func (gl *GoLog) TraceFn(fn func() string) {
+	gl.write(gl.newEntry(LTrace, "%s", lazyArgs(fn)))
+}
+
+// DebugFn logs the message fn returns at LDebug. fn is not called when the
+// entry is filtered out, which keeps expensive dumps free when debug output
+// is off:
+//
+//	golog.Std.DebugFn(func() string { return spew.Sdump(state) })
+func (gl *GoLog) DebugFn(fn func() string) {
+	gl.write(gl.newEntry(LDebug, "%s", lazyArgs(fn)))
+}
+
+func (gl *GoLog) InfoFn(fn func() string) {
+	gl.write(gl.newEntry(LInfo, "%s", lazyArgs(fn)))
+}
+
+func (gl *GoLog) NoticeFn(fn func() string) {
+	gl.write(gl.newEntry(LNotice, "%s", lazyArgs(fn)))
+}
+
+func (gl *GoLog) WarnFn(fn func() string) {
+	gl.write(gl.newEntry(LWarning, "%s", lazyArgs(fn)))
+}
+
+func (gl *GoLog) ErrorFn(fn func() string) {
+	gl.write(gl.newEntry(LError, "%s", lazyArgs(fn)))
+}
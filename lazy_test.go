@@ -0,0 +1,85 @@
+package golog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+type countingStringer struct {
+	calls *int
+}
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "stringer"
+}
+
+func TestLazy(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	var lazyCalls, stringerCalls int
+	expensive := golog.Lazy(func() interface{} {
+		lazyCalls++
+		return "expensive"
+	})
+	stringer := countingStringer{calls: &stringerCalls}
+
+	golog.Debug("%v %v", expensive, stringer)
+	if lazyCalls != 0 || stringerCalls != 0 {
+		t.Fatalf("suppressed entry evaluated its arguments: %d lazy, %d stringer", lazyCalls, stringerCalls)
+	}
+
+	args := []interface{}{expensive, stringer}
+	golog.Info("%v %v", args...)
+	if lazyCalls != 1 || stringerCalls != 1 {
+		t.Errorf("expected one evaluation each, got %d lazy, %d stringer", lazyCalls, stringerCalls)
+	}
+	if _, ok := args[0].(golog.Lazy); !ok {
+		t.Error("the caller's arguments were modified")
+	}
+	if messages := sink.messages(); len(messages) != 1 || messages[0] != "expensive stringer" {
+		t.Errorf("unexpected messages %q", messages)
+	}
+}
+
+func TestLazyFn(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	calls := 0
+	dump := func() string {
+		calls++
+		return "100% dumped"
+	}
+
+	golog.Std.DebugFn(dump)
+	golog.Std.Debugw("state", "dump", golog.Lazy(func() interface{} { return dump() }))
+	if calls != 0 {
+		t.Fatalf("disabled entries called fn %d times", calls)
+	}
+
+	golog.Std.InfoFn(dump)
+	golog.Std.Infow("state", "dump", golog.Lazy(func() interface{} { return dump() }))
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+	if entry := sink.entries[0]; entry.Message != "100% dumped" || !strings.HasPrefix(entry.Caller, "lazy_test.go:") {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if value := sink.entries[1].Fields[0].Value; value != "100% dumped" {
+		t.Errorf("unexpected field value %v", value)
+	}
+}
@@ -0,0 +1,75 @@
+package golog
+
+import (
+	"regexp"
+	"sync"
+)
+
+// fileLevels overrides MinLevel for callers matching caller patterns (see
+// AllowCallers for the syntax).
+type fileLevels struct {
+	rules []fileLevelRule
+
+	decisions sync.Map
+}
+
+type fileLevelRule struct {
+	pattern *regexp.Regexp
+	level   Level
+}
+
+type fileLevelDecision struct {
+	level Level
+	ok    bool
+}
+
+// SetFileLevel overrides MinLevel for entries logged from files or packages
+// matching pattern, e.g. SetFileLevel("store/*.go", LTrace). Later calls
+// take precedence over earlier ones for callers matching both.
+func (gl *GoLog) SetFileLevel(pattern string, level Level) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	fl := &fileLevels{}
+	if gl.fileLevels != nil {
+		fl.rules = append(fl.rules, gl.fileLevels.rules...)
+	}
+	fl.rules = append(fl.rules, fileLevelRule{pattern: compileCallerPattern(pattern), level: level})
+
+	gl.fileLevels = fl
+	gl.publish()
+}
+
+func (gl *GoLog) ResetFileLevels() {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.fileLevels = nil
+	gl.publish()
+}
+
+func (c *config) fileLevel(entry *Entry) (Level, bool) {
+	fl := c.fileLevels
+	if fl == nil {
+		return unknownLevel, false
+	}
+
+	key := entry.File + "\x00" + entry.Function
+	if d, ok := fl.decisions.Load(key); ok {
+		decision := d.(fileLevelDecision)
+		return decision.level, decision.ok
+	}
+
+	var decision fileLevelDecision
+	pkg := packageOf(entry.Function)
+	for i := len(fl.rules) - 1; i >= 0; i-- {
+		rule := fl.rules[i]
+		if rule.pattern.MatchString(entry.File) || rule.pattern.MatchString(pkg) {
+			decision = fileLevelDecision{level: rule.level, ok: true}
+			break
+		}
+	}
+	fl.decisions.Store(key, decision)
+
+	return decision.level, decision.ok
+}
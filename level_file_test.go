@@ -0,0 +1,32 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestFileLevel(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.Std.SetFileLevel("other/*.go", golog.LError)
+	golog.Debug("default level")
+
+	golog.Std.SetFileLevel("level_file_test.go", golog.LTrace)
+	golog.Trace("overridden")
+
+	golog.Std.SetFileLevel("github.com/miyaizu/golog_test", golog.LWarning)
+	golog.Info("later override wins")
+
+	golog.Std.ResetFileLevels()
+	golog.Info("reset")
+
+	if got, want := sink.messages(), []string{"overridden", "reset"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
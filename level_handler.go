@@ -0,0 +1,167 @@
+package golog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type levelHandler struct {
+	mu      sync.Mutex
+	changes map[string]uint64
+}
+
+type levelRequest struct {
+	Level    string `json:"level"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type levelResponse struct {
+	Logger  string            `json:"logger,omitempty"`
+	Level   string            `json:"level"`
+	Loggers map[string]string `json:"loggers,omitempty"`
+	Until   string            `json:"until,omitempty"`
+}
+
+// LevelHandler returns an http.Handler that reads and changes minimum
+// levels at runtime, e.g. on a debug mux:
+//
+//	mux.Handle("/debug/loglevel", golog.LevelHandler())
+//
+// GET returns the level of Std, Err and Split with those of the loggers
+// from GetLogger, or with ?logger=NAME that of one named logger. PUT or
+// POST sets it from a JSON body or form values:
+//
+//	curl -X PUT -d '{"level": "debug", "duration": "5m"}' .../debug/loglevel?logger=db
+//
+// An optional duration restores the previous level once it elapses, unless
+// the level was changed again in the meantime.
+func LevelHandler() http.Handler {
+	return &levelHandler{changes: map[string]uint64{}}
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("logger")
+	targets, ok := levelTargets(name)
+	if !ok {
+		writeLevelError(w, http.StatusNotFound, fmt.Errorf("golog: no logger named %q", name))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeLevelResponse(w, levelResponseFor(name, targets[0]))
+	case http.MethodPut, http.MethodPost:
+		var req levelRequest
+		if contentType := r.Header.Get("Content-Type"); strings.HasPrefix(contentType, "application/x-www-form-urlencoded") ||
+			strings.HasPrefix(contentType, "multipart/form-data") {
+			req.Level, req.Duration = r.FormValue("level"), r.FormValue("duration")
+		} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeLevelError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		level, err := ParseLevel(req.Level)
+		if err != nil {
+			writeLevelError(w, http.StatusBadRequest, err)
+			return
+		}
+		var duration time.Duration
+		if req.Duration != "" {
+			if duration, err = time.ParseDuration(req.Duration); err != nil || duration <= 0 {
+				writeLevelError(w, http.StatusBadRequest, fmt.Errorf("golog: invalid duration %q", req.Duration))
+				return
+			}
+		}
+
+		resp := levelResponseFor(name, targets[0])
+		resp.Level, resp.Loggers = trimLevel(level), nil
+		if duration > 0 {
+			resp.Until = time.Now().Add(duration).Format(time.RFC3339)
+		}
+		h.set(name, targets, level, duration)
+		writeLevelResponse(w, resp)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeLevelError(w, http.StatusMethodNotAllowed, fmt.Errorf("golog: method %s not allowed", r.Method))
+	}
+}
+
+// set changes the level of targets, restoring their previous levels after
+// duration if it is positive.
+func (h *levelHandler) set(name string, targets []*GoLog, level Level, duration time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	previous := make([]Level, len(targets))
+	for i, gl := range targets {
+		previous[i] = gl.config().minLevel
+		gl.SetMinLevel(level)
+	}
+
+	h.changes[name]++
+	change := h.changes[name]
+
+	if duration <= 0 {
+		return
+	}
+	time.AfterFunc(duration, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if h.changes[name] != change {
+			return
+		}
+		for i, gl := range targets {
+			gl.SetMinLevel(previous[i])
+		}
+	})
+}
+
+// levelTargets returns the loggers a request for name affects, the one
+// reported first: the current logger, Std, Err and Split when name is
+// empty, otherwise the registered logger.
+func levelTargets(name string) ([]*GoLog, bool) {
+	if name == "" {
+		targets := []*GoLog{getCurrentLogger()}
+		for _, gl := range []*GoLog{getStdLogger(), getErrLogger(), getSplitLogger()} {
+			if gl != targets[0] {
+				targets = append(targets, gl)
+			}
+		}
+		return targets, true
+	}
+
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+
+	gl, ok := loggers[name]
+
+	return []*GoLog{gl}, ok
+}
+
+func levelResponseFor(name string, gl *GoLog) levelResponse {
+	resp := levelResponse{Logger: name, Level: trimLevel(gl.config().minLevel)}
+	if name == "" {
+		resp.Loggers = map[string]string{}
+		for _, name := range LoggerNames() {
+			resp.Loggers[name] = trimLevel(GetLogger(name).config().minLevel)
+		}
+	}
+
+	return resp
+}
+
+func writeLevelResponse(w http.ResponseWriter, resp levelResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeLevelError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,83 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLevelHandler(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	db := golog.GetLogger("handler.db")
+	db.SetMinLevel(golog.LWarning)
+
+	srv := httptest.NewServer(golog.LevelHandler())
+	defer srv.Close()
+
+	do := func(method, query, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(method, srv.URL+query, strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		var out map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&out)
+		return resp.StatusCode, out
+	}
+
+	if status, out := do(http.MethodGet, "", ""); status != http.StatusOK || out["level"] != "info" ||
+		out["loggers"].(map[string]interface{})["handler.db"] != "warn" {
+		t.Errorf("GET: %d %v", status, out)
+	}
+
+	if status, out := do(http.MethodPut, "?logger=handler.db", `{"level": "debug"}`); status != http.StatusOK || out["level"] != "debug" {
+		t.Errorf("PUT: %d %v", status, out)
+	}
+	if !db.Enabled(golog.LDebug) || golog.Std.Enabled(golog.LDebug) {
+		t.Error("PUT changed the wrong logger")
+	}
+
+	if status, _ := do(http.MethodPut, "", `{"level": "loud"}`); status != http.StatusBadRequest {
+		t.Errorf("invalid level: %d", status)
+	}
+	if status, _ := do(http.MethodGet, "?logger=handler.missing", ""); status != http.StatusNotFound {
+		t.Errorf("missing logger: %d", status)
+	}
+	if status, _ := do(http.MethodDelete, "", ""); status != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE: %d", status)
+	}
+}
+
+func TestLevelHandlerDuration(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	srv := httptest.NewServer(golog.LevelHandler())
+	defer srv.Close()
+
+	resp, err := http.PostForm(srv.URL, url.Values{"level": {"trace"}, "duration": {"20ms"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !golog.Std.Enabled(golog.LTrace) || !golog.Err.Enabled(golog.LTrace) {
+		t.Fatalf("level not raised: %d", resp.StatusCode)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for golog.Std.Enabled(golog.LTrace) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if golog.Std.Enabled(golog.LDebug) || !golog.Std.Enabled(golog.LInfo) {
+		t.Error("level not restored after the duration")
+	}
+}
@@ -0,0 +1,60 @@
+package golog
+
+import "strings"
+
+// LevelNames maps levels to the label shown in the text header. Levels
+// missing from the map keep their name, e.g. "warn".
+type LevelNames map[Level]string
+
+// UpperLevelNames are the upper-case labels many log parsers expect.
+var UpperLevelNames = LevelNames{
+	LTrace:   "TRACE",
+	LDebug:   "DEBUG",
+	LInfo:    "INFO",
+	LNotice:  "NOTICE",
+	LWarning: "WARN",
+	LError:   "ERROR",
+	LDPanic:  "DPANIC",
+	LPanic:   "PANIC",
+	LFatal:   "FATAL",
+}
+
+// SetLevelNames replaces the level labels in the text header, e.g. with
+// UpperLevelNames or localized names, padded with spaces to width
+// characters: on the left for a positive width, on the right for a negative
+// one, not at all for 0. The default labels are SetLevelNames(nil, 6).
+// Icons from SetIcons still take precedence; JSON keeps the standard names.
+func (gl *GoLog) SetLevelNames(names LevelNames, width int) {
+	labels := make(map[Level]string)
+	for _, level := range allLevels() {
+		name, ok := names[level]
+		if !ok {
+			name = trimLevel(level)
+		}
+		labels[level] = padText(-width, name)
+	}
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.levelLabels = labels
+	gl.publish()
+}
+
+// label is the level as the text header shows it.
+func (style lineStyle) label(level Level) string {
+	if label, ok := style.labels[level]; ok {
+		return label
+	}
+
+	return level.String()
+}
+
+// name is label without padding.
+func (style lineStyle) name(level Level) string {
+	if label, ok := style.labels[level]; ok {
+		return strings.TrimSpace(label)
+	}
+
+	return trimLevel(level)
+}
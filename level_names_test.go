@@ -0,0 +1,44 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLevelNames(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelsOutput(&out, golog.LInfo, golog.LWarning)
+	golog.Std.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 5, 0, 0, time.Local) })
+
+	golog.Std.SetLevelNames(golog.UpperLevelNames, -5)
+	golog.Info("upper")
+	golog.Warn("upper")
+	golog.Std.SetLevelNames(golog.LevelNames{golog.LWarning: "警告"}, 0)
+	golog.Warn("localized")
+	golog.Info("localized")
+	golog.Std.SetLevelNames(nil, 6)
+	golog.Info("default")
+
+	want := []string{"[INFO ]", "[WARN ]", "[警告]", "[info]", "[  info]"}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]+" 2018-07-01 09:05:00 (") {
+			t.Errorf("got %q, want label %s", line, want[i])
+		}
+	}
+
+	tr := golog.NewTextReader(strings.NewReader(lines[1]))
+	if entry, err := tr.Next(); err != nil || entry.Level != golog.LWarning {
+		t.Errorf("TextReader: %+v, %v", entry, err)
+	}
+}
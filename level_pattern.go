@@ -0,0 +1,111 @@
+package golog
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var levelPatternsMu sync.Mutex
+var levelPatterns []levelPattern
+
+type levelPattern struct {
+	pattern string
+	re      *regexp.Regexp
+	level   Level
+
+	// path is set for patterns of files and packages, as opposed to
+	// logger names.
+	path bool
+}
+
+// SetLevelPattern sets the minimum level for everything pattern matches,
+// across Std, Err, Split and the loggers from GetLogger, including those
+// created later. Patterns with a slash or ending in .go match callers in
+// files or packages, as with SetFileLevel; other patterns match the names
+// of named loggers, e.g.
+//
+//	golog.SetLevelPattern("app/storage/*", golog.LTrace)
+//	golog.SetLevelPattern("db.*", golog.LDebug)
+//
+// Later patterns take precedence over earlier ones.
+func SetLevelPattern(pattern string, level Level) {
+	p := levelPattern{
+		pattern: pattern,
+		re:      compileCallerPattern(pattern),
+		level:   level,
+		path:    strings.Contains(pattern, "/") || strings.HasSuffix(pattern, ".go"),
+	}
+
+	levelPatternsMu.Lock()
+	levelPatterns = append(levelPatterns, p)
+	levelPatternsMu.Unlock()
+
+	for _, gl := range patternTargets() {
+		gl.applyLevelPattern(p)
+	}
+}
+
+// ResetLevelPatterns forgets the patterns and removes their file levels.
+// Named loggers keep the minimum level a pattern gave them.
+func ResetLevelPatterns() {
+	levelPatternsMu.Lock()
+	levelPatterns = nil
+	levelPatternsMu.Unlock()
+
+	for _, gl := range patternTargets() {
+		gl.ResetFileLevels()
+	}
+}
+
+func patternTargets() []*GoLog {
+	var targets []*GoLog
+	for _, gl := range []*GoLog{Std, Err, Split} {
+		if gl != nil {
+			targets = append(targets, gl)
+		}
+	}
+
+	loggersMu.Lock()
+	for _, gl := range loggers {
+		targets = append(targets, gl)
+	}
+	loggersMu.Unlock()
+
+	return targets
+}
+
+// applyLevelPatterns applies the patterns set so far to a new logger.
+func (gl *GoLog) applyLevelPatterns() {
+	levelPatternsMu.Lock()
+	patterns := levelPatterns
+	levelPatternsMu.Unlock()
+
+	for _, p := range patterns {
+		gl.applyLevelPattern(p)
+	}
+}
+
+func (gl *GoLog) applyLevelPattern(p levelPattern) {
+	switch {
+	case p.path:
+		gl.SetFileLevel(p.pattern, p.level)
+	case gl.name != "" && p.re.MatchString(gl.name):
+		gl.SetMinLevel(p.level)
+	}
+}
+
+// patternLevel returns the level of the last pattern matching a logger
+// name.
+func patternLevel(name string) (Level, bool) {
+	levelPatternsMu.Lock()
+	defer levelPatternsMu.Unlock()
+
+	for i := len(levelPatterns) - 1; i >= 0; i-- {
+		if !levelPatterns[i].path && levelPatterns[i].re.MatchString(name) {
+			return levelPatterns[i].level, true
+		}
+	}
+
+	return unknownLevel, false
+}
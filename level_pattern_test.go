@@ -0,0 +1,45 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLevelPattern(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	defer golog.ResetLevelPatterns()
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	golog.SetLevelPattern("cache.*", golog.LDebug)
+	golog.GetLogger("cache.lru").Debug("by name")
+	golog.GetLogger("queue").Debug("hidden")
+
+	// Name patterns leave callers alone, even in files they match.
+	golog.SetLevelPattern("level_pattern_test.*", golog.LDebug)
+	if golog.Std.Enabled(golog.LDebug) {
+		t.Error("name patterns enabled LDebug on Std")
+	}
+	golog.Debug("hidden")
+
+	golog.SetLevelPattern("level_pattern_test.go", golog.LTrace)
+	golog.Trace("by file")
+
+	// Patterns survive setting the logger up again.
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.Std.AddSink(sink)
+	golog.Trace("after setup")
+
+	golog.ResetLevelPatterns()
+	golog.Trace("reset")
+
+	want := []string{"by name", "by file", "after setup"}
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package golog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fatih/color"
+)
+
+var builtinLevels = []Level{LTrace, LDebug, LInfo, LNotice, LWarning, LError, LDPanic, LPanic, LFatal}
+
+// LevelOption describes a level added with RegisterLevel.
+type LevelOption struct {
+	// Name is the level's label, e.g. "audit"; ParseLevel accepts it in
+	// any case.
+	Name string
+
+	// Color of the label on the console; white when nil.
+	Color *color.Color
+
+	// Syslog is the syslog severity, 0 (emergency) to 7 (debug), used by
+	// the syslog, journald and GELF sinks and mapped to the Cloud Logging
+	// severity of FormatGCP.
+	Syslog int
+}
+
+type customLevel struct {
+	name   string
+	color  *color.Color
+	syslog int
+}
+
+var customLevelsMu sync.Mutex
+
+// customLevels holds a map[Level]customLevel, replaced on every
+// registration so the write path reads it without locking.
+var customLevels atomic.Value
+
+// RegisterLevel adds a level between the built-in ones, which are spaced
+// apart for it, e.g. for a distinct audit severity:
+//
+//	const LAudit = golog.LNotice + 5
+//
+//	func init() {
+//		golog.RegisterLevel(LAudit, golog.LevelOption{Name: "audit", Syslog: 5})
+//	}
+//
+//	golog.Std.LogLevel(LAudit, "user %s granted admin", name)
+//
+// Filtering treats it like any level, by its value. Register levels before
+// logging with them, typically in init.
+func RegisterLevel(level Level, option LevelOption) error {
+	name := strings.TrimSpace(option.Name)
+	if level == unknownLevel || level%levelStep == 0 {
+		return fmt.Errorf("golog: level %d is reserved", level)
+	}
+	if name == "" || strings.ContainsAny(name, " \t[]") {
+		return fmt.Errorf("golog: invalid level name %q", option.Name)
+	}
+	if option.Syslog < 0 || option.Syslog > 7 {
+		return fmt.Errorf("golog: invalid syslog severity %d", option.Syslog)
+	}
+
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+
+	if _, ok := lookupLevel(level); ok {
+		return fmt.Errorf("golog: level %d is already registered", level)
+	}
+	if _, err := ParseLevel(name); err == nil {
+		return fmt.Errorf("golog: level name %q is taken", name)
+	}
+
+	old, _ := customLevels.Load().(map[Level]customLevel)
+	levels := make(map[Level]customLevel, len(old)+1)
+	for l, custom := range old {
+		levels[l] = custom
+	}
+	levels[level] = customLevel{name: name, color: option.Color, syslog: option.Syslog}
+	customLevels.Store(levels)
+
+	return nil
+}
+
+func lookupLevel(level Level) (customLevel, bool) {
+	levels, _ := customLevels.Load().(map[Level]customLevel)
+	custom, ok := levels[level]
+
+	return custom, ok
+}
+
+// allLevels returns the built-in and registered levels in order.
+func allLevels() []Level {
+	levels, _ := customLevels.Load().(map[Level]customLevel)
+	if len(levels) == 0 {
+		return builtinLevels
+	}
+
+	all := append([]Level(nil), builtinLevels...)
+	for level := range levels {
+		all = append(all, level)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	return all
+}
+
+// LogLevel logs at level, which may be one added with RegisterLevel. Unlike
+// DPanic, Panic and Fatal it never panics or exits.
+func (gl *GoLog) LogLevel(level Level, text string, args ...interface{}) {
+	gl.write(gl.newEntry(level, text, args))
+}
+
+func LogLevel(level Level, text string, args ...interface{}) {
+	logger := getCurrentLogger()
+	logger.write(logger.newEntry(level, text, args))
+}
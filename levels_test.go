@@ -0,0 +1,63 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/miyaizu/golog"
+)
+
+const lAudit = golog.LNotice + 5
+
+func init() {
+	if err := golog.RegisterLevel(lAudit, golog.LevelOption{Name: "audit", Color: color.New(color.FgCyan), Syslog: 5}); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LNotice})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(lAudit, &out)
+	golog.LogLevel(lAudit, "granted %s", "admin")
+	golog.Std.SetMinLevel(golog.LWarning)
+	golog.Std.LogLevel(lAudit, "filtered")
+
+	if got := out.String(); !strings.HasPrefix(got, "[ audit] ") || !strings.HasSuffix(got, "): granted admin\n") {
+		t.Errorf("unexpected output %q", got)
+	}
+	if level, err := golog.ParseLevel("AUDIT"); err != nil || level != lAudit {
+		t.Errorf("ParseLevel: %v, %v", level, err)
+	}
+
+	for _, test := range []struct {
+		level golog.Level
+		name  string
+	}{
+		{lAudit, "other"},
+		{golog.LWarning, "other"},
+		{golog.LNotice + 6, "warn"},
+		{golog.LNotice + 6, ""},
+	} {
+		if err := golog.RegisterLevel(test.level, golog.LevelOption{Name: test.name}); err == nil {
+			t.Errorf("RegisterLevel(%d, %q) succeeded", test.level, test.name)
+		}
+	}
+}
+
+func TestCustomLevelGCP(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo, Format: golog.FormatGCP})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(lAudit, &out)
+	golog.Std.LogLevel(lAudit, "granted")
+
+	if got := out.String(); !strings.HasPrefix(got, `{"severity":"NOTICE",`) {
+		t.Errorf("unexpected line %q", got)
+	}
+}
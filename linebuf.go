@@ -0,0 +1,68 @@
+package golog
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxPooledLine keeps the occasional huge entry from pinning its buffer.
+const maxPooledLine = 64 << 10
+
+var linePool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 512)
+		return &b
+	},
+}
+
+func getLineBuffer() *[]byte {
+	b := linePool.Get().(*[]byte)
+	*b = (*b)[:0]
+
+	return b
+}
+
+func putLineBuffer(b *[]byte) {
+	if cap(*b) <= maxPooledLine {
+		linePool.Put(b)
+	}
+}
+
+// defaultHeader is shared by every logger that has not set its own header,
+// which lets appendHeader recognize it and skip template execution.
+var defaultHeader = parseDefaultHeader()
+
+// appendDefaultHeader appends what defaultHeader renders for the given
+// values.
+func appendDefaultHeader(dst []byte, level string, t time.Time, layout, prefix, logger, caller string, goroutine uint64, id string) []byte {
+	dst = append(dst, '[')
+	dst = append(dst, level...)
+	dst = append(dst, "] "...)
+	dst = t.AppendFormat(dst, layout)
+	dst = append(dst, ' ')
+	if prefix != "" {
+		dst = append(dst, '[')
+		dst = append(dst, prefix...)
+		dst = append(dst, "] "...)
+	}
+	if logger != "" {
+		dst = append(dst, logger...)
+		dst = append(dst, ' ')
+	}
+	dst = append(dst, '(')
+	dst = append(dst, caller...)
+	dst = append(dst, ')')
+	if goroutine != 0 {
+		dst = append(dst, " #"...)
+		dst = strconv.AppendUint(dst, goroutine, 10)
+	}
+	if id != "" {
+		dst = append(dst, " ["...)
+		dst = append(dst, id...)
+		dst = append(dst, ']')
+	}
+	dst = append(dst, ": "...)
+
+	return dst
+}
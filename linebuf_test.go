@@ -0,0 +1,52 @@
+package golog_test
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestConsoleLine(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LInfo, &out)
+	golog.Std.SetClock(func() time.Time { return time.Date(2018, 7, 1, 9, 30, 0, 0, time.Local) })
+	golog.Std.SetEntryIDs(golog.IDUUID)
+
+	golog.Info("done")
+	golog.Info("user %s", "<admin>")
+
+	want := regexp.MustCompile(`^\[  info\] 2018-07-01 09:30:00 \(linebuf_test\.go:\d+\) \[[0-9a-f-]{36}\]: done\n` +
+		`\[  info\] 2018-07-01 09:30:00 \(linebuf_test\.go:\d+\) \[[0-9a-f-]{36}\]: user <admin>\n$`)
+	if !want.Match(out.Bytes()) {
+		t.Errorf("unexpected lines %q", out.String())
+	}
+}
+
+func TestInfoAllocations(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.Std.SetLevelOutput(golog.LInfo, io.Discard)
+
+	// The entry and its caller.
+	if n := testing.AllocsPerRun(100, func() { golog.Info("request handled") }); n > 5 {
+		t.Errorf("expected at most 5 allocations per entry, got %v", n)
+	}
+}
+
+func BenchmarkInfo(b *testing.B) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.Std.SetLevelOutput(golog.LInfo, io.Discard)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		golog.Info("request %d handled", i)
+	}
+}
@@ -0,0 +1,120 @@
+// Package logrgolog implements a logr.LogSink on top of golog, for
+// libraries such as controller-runtime that only accept a logr.Logger.
+package logrgolog
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/go-logr/logr"
+
+	"github.com/miyaizu/golog"
+)
+
+// Sink writes logr records to a GoLog. V(0) logs at LInfo, V(1) at LDebug
+// and higher verbosities at LTrace; errors log at LError with an "error"
+// field. Names set with WithName are joined with "/" into a "logger" field.
+type Sink struct {
+	gl     *golog.GoLog
+	name   string
+	fields []golog.Field
+	depth  int
+}
+
+// New returns a logr.Logger writing to gl:
+//
+//	ctrl.SetLogger(logrgolog.New(golog.Std))
+func New(gl *golog.GoLog) logr.Logger {
+	return logr.New(&Sink{gl: gl})
+}
+
+// Level maps a logr verbosity to a golog level.
+func Level(v int) golog.Level {
+	switch {
+	case v <= 0:
+		return golog.LInfo
+	case v == 1:
+		return golog.LDebug
+	}
+
+	return golog.LTrace
+}
+
+func (s *Sink) Init(info logr.RuntimeInfo) {
+	s.depth = info.CallDepth
+}
+
+func (s *Sink) Enabled(level int) bool {
+	return s.gl.Enabled(Level(level))
+}
+
+func (s *Sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.gl.LogAt(s.caller(), Level(level), msg, s.entryFields(nil, keysAndValues)...)
+}
+
+func (s *Sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	fields := []golog.Field{{Key: "error", Value: err}}
+	s.gl.LogAt(s.caller(), golog.LError, msg, s.entryFields(fields, keysAndValues)...)
+}
+
+// caller is the call site of the logr.Logger method that called Info or
+// Error.
+func (s *Sink) caller() uintptr {
+	var pcs [1]uintptr
+	runtime.Callers(3+s.depth, pcs[:])
+
+	return pcs[0]
+}
+
+func (s *Sink) entryFields(fields []golog.Field, keysAndValues []interface{}) []golog.Field {
+	var all []golog.Field
+	if s.name != "" {
+		all = append(all, golog.Field{Key: "logger", Value: s.name})
+	}
+	all = append(all, s.fields...)
+	all = append(all, fields...)
+
+	return appendKeysAndValues(all, keysAndValues)
+}
+
+func (s *Sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	clone := *s
+	clone.fields = appendKeysAndValues(append([]golog.Field(nil), s.fields...), keysAndValues)
+
+	return &clone
+}
+
+func (s *Sink) WithName(name string) logr.LogSink {
+	clone := *s
+	if clone.name != "" {
+		clone.name += "/"
+	}
+	clone.name += name
+
+	return &clone
+}
+
+func (s *Sink) WithCallDepth(depth int) logr.LogSink {
+	clone := *s
+	clone.depth += depth
+
+	return &clone
+}
+
+// appendKeysAndValues appends logr's alternating keys and values as fields.
+func appendKeysAndValues(fields []golog.Field, keysAndValues []interface{}) []golog.Field {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		fields = append(fields, golog.Field{Key: key, Value: value})
+	}
+
+	return fields
+}
@@ -0,0 +1,68 @@
+package logrgolog_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/miyaizu/golog"
+	"github.com/miyaizu/golog/logrgolog"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []golog.Entry
+}
+
+func (s *memorySink) Write(entry *golog.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, *entry)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	logger := logrgolog.New(golog.Std).WithName("controller").WithValues("kind", "Pod")
+	logger.Info("reconciled", "name", "web-1")
+	logger.V(1).Info("cache hit")
+	logger.V(2).Info("dropped")
+	logger.WithName("events").Error(errors.New("timeout"), "sync failed")
+
+	if len(sink.entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(sink.entries))
+	}
+
+	entry := sink.entries[0]
+	if entry.Level != golog.LInfo || entry.Message != "reconciled" || entry.Caller != "logrgolog_test.go:37" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	want := []golog.Field{{Key: "logger", Value: "controller"}, {Key: "kind", Value: "Pod"}, {Key: "name", Value: "web-1"}}
+	if len(entry.Fields) != len(want) {
+		t.Fatalf("unexpected fields %+v", entry.Fields)
+	}
+	for i, field := range want {
+		if entry.Fields[i] != field {
+			t.Errorf("field %d: got %+v, want %+v", i, entry.Fields[i], field)
+		}
+	}
+
+	if entry := sink.entries[1]; entry.Level != golog.LDebug {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	entry = sink.entries[2]
+	if entry.Level != golog.LError || entry.Caller != "logrgolog_test.go:40" || entry.Fields[0].Value != "controller/events" || entry.Fields[2].Key != "error" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,66 @@
+package golog
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MultilineMode sets how console lines show messages and stack traces that
+// span several lines.
+type MultilineMode uint8
+
+const (
+	// MultilineKeep writes continuation lines as they are.
+	MultilineKeep MultilineMode = iota
+
+	// MultilineIndent indents continuation lines under the start of the
+	// message, as SetWrap does.
+	MultilineIndent
+
+	// MultilineMarker starts continuation lines with "| ", so they cannot
+	// pass for the header of another entry.
+	MultilineMarker
+
+	// MultilineHeader repeats the header on every continuation line, so
+	// each line is a complete entry for grep and line-oriented parsers.
+	MultilineHeader
+
+	// MultilineEscape writes line breaks as \n, keeping each entry on a
+	// single line as JSON and GCP lines always are.
+	MultilineEscape
+)
+
+const continuationMarker = "| "
+
+// SetMultiline sets how console lines continue multi-line messages, such
+// as stack traces or YAML dumps, which otherwise break tools that read a
+// log line by line. With SetWrap, lines are wrapped and indented instead.
+func (gl *GoLog) SetMultiline(mode MultilineMode) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.multiline = mode
+	gl.publish()
+}
+
+var lineBreaks = strings.NewReplacer("\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+
+// continueLines renders the continuation lines of text, which follows
+// header, as mode asks.
+func continueLines(mode MultilineMode, header, text string) string {
+	var prefix string
+	switch mode {
+	case MultilineIndent:
+		prefix = strings.Repeat(" ", utf8.RuneCountInString(escapeSequence.ReplaceAllString(header, "")))
+	case MultilineMarker:
+		prefix = continuationMarker
+	case MultilineHeader:
+		prefix = header
+	case MultilineEscape:
+		return lineBreaks.Replace(text)
+	default:
+		return text
+	}
+
+	return strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
@@ -0,0 +1,52 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetMultiline(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	for _, test := range []struct {
+		mode golog.MultilineMode
+		want func(header string) string
+	}{
+		{golog.MultilineKeep, func(header string) string { return header + "a: 1\nb: 2\n" }},
+		{golog.MultilineIndent, func(header string) string {
+			return header + "a: 1\n" + strings.Repeat(" ", len(header)) + "b: 2\n"
+		}},
+		{golog.MultilineMarker, func(header string) string { return header + "a: 1\n| b: 2\n" }},
+		{golog.MultilineHeader, func(header string) string { return header + "a: 1\n" + header + "b: 2\n" }},
+		{golog.MultilineEscape, func(header string) string { return header + `a: 1\nb: 2` + "\n" }},
+	} {
+		var out bytes.Buffer
+		golog.Std.SetLevelOutput(golog.LInfo, &out)
+		golog.Std.SetMultiline(test.mode)
+		golog.Info("a: 1\nb: 2")
+
+		line := out.String()
+		header := line[:strings.Index(line, "a: 1")]
+		if want := test.want(header); line != want {
+			t.Errorf("mode %d: got %q, want %q", test.mode, line, want)
+		}
+	}
+}
+
+func TestSetMultilineJSON(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo, Format: golog.FormatJSON})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LInfo, &out)
+	golog.Std.SetMultiline(golog.MultilineHeader)
+	golog.Info("a: 1\nb: 2")
+
+	if line := out.String(); strings.Count(line, "\n") != 1 || !strings.Contains(line, `"message":"a: 1\nb: 2"`) {
+		t.Errorf("unexpected line %q", line)
+	}
+}
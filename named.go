@@ -0,0 +1,267 @@
+package golog
+
+import (
+	"io"
+	"sort"
+	"sync"
+)
+
+var loggersMu sync.Mutex
+var loggers = map[string]*GoLog{}
+
+// GetLogger returns the logger registered under name, creating it from the
+// current logger on first use:
+//
+//	var dbLog = golog.GetLogger("db")
+//	...
+//	golog.GetLogger("db").SetMinLevel(golog.LDebug)
+//
+// Named loggers let each subsystem have its own verbosity; see Named. They
+// follow the current logger as SetOutput switches it, and start over from
+// the new one when SetupLogger runs. Loggers obtained before SetupLogger,
+// e.g. in package variables, discard entries until it runs.
+func GetLogger(name string) *GoLog {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+
+	if gl, ok := loggers[name]; ok {
+		return gl
+	}
+
+	gl := &GoLog{name: name, out: io.Discard}
+	if glcur != nil {
+		glcur.adopt(gl, name)
+	} else {
+		gl.mu.Lock()
+		gl.publish()
+		gl.mu.Unlock()
+	}
+	gl.applyLevelPatterns()
+	loggers[name] = gl
+
+	return gl
+}
+
+// LoggerNames returns the names registered with GetLogger, sorted.
+func LoggerNames() []string {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// resetLoggers derives the registered loggers again from the current
+// logger, after SetupLogger replaced it.
+func resetLoggers() {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+
+	for name, gl := range loggers {
+		glcur.adopt(gl, name)
+		gl.applyLevelPatterns()
+	}
+}
+
+// followCurrentLogger moves the registered loggers under the current
+// logger, keeping their own settings, after SetOutput switched it.
+func followCurrentLogger() {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+
+	for _, gl := range loggers {
+		if gl.parent != glcur {
+			glcur.reparent(gl)
+		}
+	}
+}
+
+// Named returns a new logger that starts with gl's levels, console
+// settings and output, and shows name in the header before the caller.
+// Those settings change independently of gl's afterwards. Entries go to
+// gl's sinks, including those added later, and through gl's buffer, async
+// queue, escalations, budget and other filters unless the named logger
+// sets its own; sinks added to it only get its entries. Close gl rather
+// than the named logger. Names of loggers derived from named loggers are
+// joined with dots, e.g. "db.pool".
+//
+// gl keeps track of its named loggers, so derive them once, e.g. per
+// subsystem, rather than per request.
+func (gl *GoLog) Named(name string) *GoLog {
+	child := &GoLog{}
+	gl.adopt(child, name)
+
+	return child
+}
+
+// adopt makes child a named logger of gl, dropping whatever settings it
+// had.
+func (gl *GoLog) adopt(child *GoLog, name string) {
+	gl.detach(child)
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	child.mu.Lock()
+	defer child.mu.Unlock()
+
+	child.MinLevel = gl.MinLevel
+	child.DefaultLevel = gl.DefaultLevel
+	child.SampledMinLevel = gl.SampledMinLevel
+	child.Colorize = gl.Colorize
+	child.forceColor = gl.forceColor
+	child.ColorMode = gl.ColorMode
+	child.Format = gl.Format
+	child.Header = gl.Header
+	child.UserHeader = gl.UserHeader
+	child.EntryIDs = gl.EntryIDs
+	child.CallerFormat = gl.CallerFormat
+	child.callerFunction = gl.callerFunction
+	child.noCaller = gl.noCaller
+	child.CallerRoot = gl.CallerRoot
+	child.callerLink = gl.callerLink
+	child.hyperlinks = gl.hyperlinks
+	child.Development = gl.Development
+	child.exitCode = gl.exitCode
+	child.exitFunc = gl.exitFunc
+	child.Fingerprints = gl.Fingerprints
+	child.icons = gl.icons
+	child.levelLabels = gl.levelLabels
+	child.levelColors = gl.levelColors
+	child.levelOuts = gl.levelOuts
+	child.clock = gl.clock
+	child.timeFormat = gl.timeFormat
+	child.utc = gl.utc
+	child.precision = gl.precision
+	child.app = gl.app
+	child.version = gl.version
+	child.goroutines = gl.goroutines
+	child.prefix = gl.prefix
+	child.multiline = gl.multiline
+	child.hexLimit = gl.hexLimit
+
+	// The rest is shared with gl through inherit.
+	child.sinks = nil
+	child.escalations = nil
+	child.profiles = nil
+	child.budget = nil
+	child.digest = nil
+	child.limiter = nil
+	child.repeats = nil
+	child.async = nil
+	child.samplers = nil
+	child.callers = nil
+	child.fileLevels = nil
+	child.buffer = nil
+	child.exitHooks = nil
+	child.wrap = 0
+	child.pprofLabels = false
+
+	child.name = name
+	if gl.name != "" {
+		child.name = gl.name + "." + name
+	}
+
+	gl.link(child)
+}
+
+// reparent moves child under gl, keeping its own settings.
+func (gl *GoLog) reparent(child *GoLog) {
+	gl.detach(child)
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	child.mu.Lock()
+	defer child.mu.Unlock()
+
+	gl.link(child)
+}
+
+// link makes gl the parent of child and writes child to gl's outputs; both
+// mutexes must be held.
+func (gl *GoLog) link(child *GoLog) {
+	child.parent = gl
+	child.out = gl.out
+	child.errOut = gl.errOut
+	child.terminals = gl.terminals
+	if indexLogger(gl.children, child) < 0 {
+		gl.children = append(gl.children, child)
+	}
+
+	child.publish()
+}
+
+// detach removes child from the named loggers of its previous parent, if
+// that is not gl. It takes the mutexes one at a time, as a parent's is
+// always taken before its children's.
+func (gl *GoLog) detach(child *GoLog) {
+	child.mu.Lock()
+	old := child.parent
+	child.mu.Unlock()
+
+	if old == nil || old == gl {
+		return
+	}
+
+	old.mu.Lock()
+	defer old.mu.Unlock()
+
+	if i := indexLogger(old.children, child); i >= 0 {
+		old.children = append(old.children[:i:i], old.children[i+1:]...)
+	}
+}
+
+func indexLogger(loggers []*GoLog, gl *GoLog) int {
+	for i, l := range loggers {
+		if l == gl {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// inherit adds gl's own sinks, escalations and exit hooks to its parent's
+// and falls back to the parent's write path state, so that changes to the
+// parent reach its named loggers; gl.mu must be held.
+func (gl *GoLog) inherit(c *config) {
+	if gl.parent == nil {
+		return
+	}
+	p := gl.parent.config()
+
+	c.sinks = append(p.sinks[:len(p.sinks):len(p.sinks)], gl.sinks...)
+	c.escalations = append(p.escalations[:len(p.escalations):len(p.escalations)], gl.escalations...)
+	c.exitHooks = append(p.exitHooks[:len(p.exitHooks):len(p.exitHooks)], gl.exitHooks...)
+
+	if c.budget == nil {
+		c.budget = p.budget
+	}
+	if c.digest == nil {
+		c.digest = p.digest
+	}
+	if c.limiter == nil {
+		c.limiter = p.limiter
+	}
+	if c.repeats == nil {
+		c.repeats = p.repeats
+	}
+	if c.async == nil {
+		c.async = p.async
+	}
+	if c.samplers == nil {
+		c.samplers = p.samplers
+	}
+	if c.buffer == nil {
+		c.buffer = p.buffer
+	}
+	if c.wrap == 0 {
+		c.wrap = p.wrap
+	}
+}
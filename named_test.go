@@ -0,0 +1,85 @@
+package golog_test
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestGetLogger(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	db := golog.GetLogger("db")
+	if golog.GetLogger("db") != db {
+		t.Fatal("GetLogger returned a different logger for the same name")
+	}
+
+	var out bytes.Buffer
+	db.SetLevelOutput(golog.LDebug, &out)
+	db.SetMinLevel(golog.LDebug)
+	db.Debug("query %d", 1)
+	golog.Debug("hidden")
+	db.Named("pool").Info("exhausted")
+
+	if line := out.String(); !strings.Contains(line, " db (named_test.go:") || !strings.HasSuffix(line, "): query 1\n") {
+		t.Errorf("unexpected line %q", line)
+	}
+	if got, want := sink.messages(), []string{"query 1", "exhausted"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if sink.entries[0].Logger != "db" || sink.entries[1].Logger != "db.pool" {
+		t.Errorf("unexpected logger names %q, %q", sink.entries[0].Logger, sink.entries[1].Logger)
+	}
+
+	names := golog.LoggerNames()
+	if i := sort.SearchStrings(names, "db"); i == len(names) || names[i] != "db" {
+		t.Errorf("db missing from %q", names)
+	}
+}
+
+func TestNamedFollowsParent(t *testing.T) {
+	var out bytes.Buffer
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.SetLevelOutput(golog.LInfo, &out)
+	worker := gl.Named("worker")
+
+	sink := &memorySink{}
+	gl.AddSink(sink)
+	worker.SetMinLevel(golog.LWarning)
+	worker.Info("hidden")
+	worker.Warn("retrying")
+
+	if got, want := sink.messages(), []string{"retrying"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if gl.Info("shown"); !strings.Contains(out.String(), "shown") {
+		t.Errorf("parent level changed with the named logger's: %q", out.String())
+	}
+}
+
+func TestNamedSetOutputToggle(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	golog.GetLogger("toggle")
+
+	before := golog.NamedLoggers(golog.Std)
+	for i := 0; i < 100; i++ {
+		golog.SetOutput(golog.OStderr)
+		golog.SetOutput(golog.OStdout)
+	}
+
+	if n := golog.NamedLoggers(golog.Std); n != before {
+		t.Errorf("Std tracks %d named loggers, want %d", n, before)
+	}
+	if n := golog.NamedLoggers(golog.Err); n != 0 {
+		t.Errorf("Err still tracks %d named loggers", n)
+	}
+}
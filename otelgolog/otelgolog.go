@@ -0,0 +1,34 @@
+// Package otelgolog connects golog to OpenTelemetry tracing.
+package otelgolog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/miyaizu/golog"
+)
+
+// Sampled reports whether ctx carries a sampled OpenTelemetry span.
+func Sampled(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsSampled()
+}
+
+// TraceIDs returns the hex trace and span IDs of the span carried by ctx,
+// or empty strings if there is none.
+func TraceIDs(ctx context.Context) (traceID, spanID string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", ""
+	}
+
+	return sc.TraceID().String(), sc.SpanID().String()
+}
+
+// Install registers Sampled as golog's trace sampler, so that
+// GoLog.SampledMinLevel follows OpenTelemetry sampling decisions, and
+// TraceIDs so that entries logged with a context carry trace_id and span_id.
+func Install() {
+	golog.SetTraceSampler(Sampled)
+	golog.SetTraceIDs(TraceIDs)
+}
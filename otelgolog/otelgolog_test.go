@@ -0,0 +1,45 @@
+package otelgolog_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/miyaizu/golog/otelgolog"
+)
+
+func TestSampled(t *testing.T) {
+	config := trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	}
+
+	unsampled := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(config))
+	config.TraceFlags = trace.FlagsSampled
+	sampled := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(config))
+
+	if otelgolog.Sampled(context.Background()) || otelgolog.Sampled(unsampled) {
+		t.Error("unsampled context reported as sampled")
+	}
+	if !otelgolog.Sampled(sampled) {
+		t.Error("sampled context reported as unsampled")
+	}
+}
+
+func TestTraceIDs(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x0a, 15: 0x01},
+		SpanID:  trace.SpanID{0x0b, 7: 0x02},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	traceID, spanID := otelgolog.TraceIDs(ctx)
+	if traceID != "0a000000000000000000000000000001" || spanID != "0b00000000000002" {
+		t.Errorf("unexpected ids %q %q", traceID, spanID)
+	}
+
+	if traceID, spanID := otelgolog.TraceIDs(context.Background()); traceID != "" || spanID != "" {
+		t.Errorf("expected no ids without a span, got %q %q", traceID, spanID)
+	}
+}
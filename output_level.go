@@ -0,0 +1,50 @@
+package golog
+
+import "io"
+
+// SetLevelOutput writes entries of exactly level to w instead of the
+// logger's output, e.g. errors to a dedicated file. To copy them rather
+// than move them, pass io.MultiWriter(os.Stderr, file). A nil w restores
+// the logger's output for level.
+func (gl *GoLog) SetLevelOutput(level Level, w io.Writer) {
+	gl.SetLevelsOutput(w, level)
+}
+
+// SetLevelsOutput routes several levels to w at once, e.g. LTrace and
+// LDebug to a debug file and LError through LFatal to an alert pipe, so one
+// logger replaces a set of loggers picked by hand. A nil w restores the
+// logger's output for the levels.
+func (gl *GoLog) SetLevelsOutput(w io.Writer, levels ...Level) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	outs := make(map[Level]io.Writer, len(gl.levelOuts)+len(levels))
+	for l, out := range gl.levelOuts {
+		outs[l] = out
+	}
+	for _, level := range levels {
+		if w != nil {
+			outs[level] = w
+		} else {
+			delete(outs, level)
+		}
+	}
+
+	gl.levelOuts = outs
+	gl.detectTerminals()
+	gl.publish()
+}
+
+// writeLine writes the formatted line of an entry at level to the writer
+// for that level.
+func (gl *GoLog) writeLine(c *config, level Level, line []byte) {
+	if w, ok := c.levelOuts[level]; ok {
+		w.Write(line)
+	} else if gl.errOut != nil && level >= LWarning {
+		gl.errOut.Write(line)
+	} else if c.buffer != nil {
+		c.buffer.write(line, level)
+	} else {
+		gl.out.Write(line)
+	}
+}
@@ -0,0 +1,74 @@
+package golog_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetLevelOutput(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var errors bytes.Buffer
+	golog.Std.SetLevelOutput(golog.LError, &errors)
+	golog.Info("routine")
+	golog.Error("broken")
+
+	golog.Std.SetLevelOutput(golog.LError, nil)
+	golog.Error("back on stdout")
+
+	if out := errors.String(); !strings.HasSuffix(out, "): broken\n") || strings.Count(out, "\n") != 1 {
+		t.Errorf("unexpected error output %q", out)
+	}
+}
+
+func TestSetLevelsOutput(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LTrace})
+	golog.SetOutput(golog.OStdout)
+
+	var debug, alerts bytes.Buffer
+	golog.Std.SetLevelsOutput(&debug, golog.LTrace, golog.LDebug)
+	golog.Std.SetLevelsOutput(&alerts, golog.LError, golog.LDPanic)
+	golog.Trace("step")
+	golog.Debug("state")
+	golog.Info("routine")
+	golog.Error("broken")
+
+	if out := debug.String(); strings.Count(out, "\n") != 2 || !strings.Contains(out, "step") || !strings.Contains(out, "state") {
+		t.Errorf("unexpected debug output %q", out)
+	}
+	if out := alerts.String(); strings.Count(out, "\n") != 1 || !strings.Contains(out, "broken") {
+		t.Errorf("unexpected alert output %q", out)
+	}
+}
+
+func TestSplitOutput(t *testing.T) {
+	if os.Getenv("GOLOG_SPLIT_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OSplit)
+		golog.Info("routine")
+		golog.Warn("attention")
+		golog.Error("failure")
+		return
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSplitOutput$")
+	cmd.Env = append(os.Environ(), "GOLOG_SPLIT_TEST=1")
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if out := stdout.String(); !strings.Contains(out, "routine") || strings.Contains(out, "attention") {
+		t.Errorf("unexpected stdout %q", out)
+	}
+	if out := stderr.String(); !strings.Contains(out, "attention") || !strings.Contains(out, "failure") || strings.Contains(out, "routine") {
+		t.Errorf("unexpected stderr %q", out)
+	}
+}
@@ -0,0 +1,269 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+)
+
+// A minimal Parquet writer: one row group, one gzip-compressed PLAIN data
+// page per column, all columns REQUIRED. That is all the sinks need and it
+// keeps the package free of a Parquet dependency.
+
+const (
+	parquetMagic = "PAR1"
+
+	parquetInt64     int32 = 2
+	parquetByteArray int32 = 6
+
+	parquetConvertedNone            int32 = -1
+	parquetConvertedUTF8            int32 = 0
+	parquetConvertedTimestampMicros int32 = 10
+
+	parquetRequired      int32 = 0
+	parquetEncodingPlain int32 = 0
+	parquetEncodingRLE   int32 = 3
+	parquetCodecGzip     int32 = 2
+	parquetDataPage      int32 = 0
+)
+
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+type parquetColumn struct {
+	name      string
+	physical  int32
+	converted int32
+	values    bytes.Buffer
+}
+
+func (c *parquetColumn) appendInt64(v int64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	c.values.Write(b[:])
+}
+
+func (c *parquetColumn) appendString(v string) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(v)))
+	c.values.Write(b[:])
+	c.values.WriteString(v)
+}
+
+func writeParquetEntries(w io.Writer, entries []Entry) error {
+	columns := []*parquetColumn{
+		{name: "time", physical: parquetInt64, converted: parquetConvertedTimestampMicros},
+		{name: "level", physical: parquetByteArray, converted: parquetConvertedUTF8},
+		{name: "caller", physical: parquetByteArray, converted: parquetConvertedUTF8},
+		{name: "message", physical: parquetByteArray, converted: parquetConvertedUTF8},
+	}
+
+	for i := range entries {
+		columns[0].appendInt64(entries[i].Time.UnixNano() / 1000)
+		columns[1].appendString(trimLevel(entries[i].Level))
+		columns[2].appendString(entries[i].Caller)
+		columns[3].appendString(entries[i].Message)
+	}
+
+	return writeParquet(w, columns, len(entries))
+}
+
+func writeParquet(w io.Writer, columns []*parquetColumn, numRows int) error {
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	chunks := make([]parquetChunkMeta, len(columns))
+	for i, column := range columns {
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		if _, err := zw.Write(column.values.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		var header thriftWriter
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(column.values.Len()))
+		header.i32(3, int32(compressed.Len()))
+		header.structField(5)
+		header.i32(1, int32(numRows))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.endStruct()
+		header.stop()
+
+		chunks[i] = parquetChunkMeta{
+			offset:           int64(file.Len()),
+			uncompressedSize: int64(header.buf.Len() + column.values.Len()),
+			compressedSize:   int64(header.buf.Len() + compressed.Len()),
+		}
+
+		file.Write(header.buf.Bytes())
+		file.Write(compressed.Bytes())
+	}
+
+	meta := parquetFileMeta(columns, chunks, numRows)
+	file.Write(meta)
+
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(meta)))
+	file.Write(size[:])
+	file.WriteString(parquetMagic)
+
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+type parquetChunkMeta struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+func parquetFileMeta(columns []*parquetColumn, chunks []parquetChunkMeta, numRows int) []byte {
+	var tw thriftWriter
+	tw.i32(1, 1)
+
+	tw.list(2, thriftStruct, len(columns)+1)
+	tw.beginStruct()
+	tw.binary(4, []byte("schema"))
+	tw.i32(5, int32(len(columns)))
+	tw.endStruct()
+	for _, column := range columns {
+		tw.beginStruct()
+		tw.i32(1, column.physical)
+		tw.i32(3, parquetRequired)
+		tw.binary(4, []byte(column.name))
+		if column.converted != parquetConvertedNone {
+			tw.i32(6, column.converted)
+		}
+		tw.endStruct()
+	}
+
+	tw.i64(3, int64(numRows))
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.uncompressedSize
+	}
+
+	tw.list(4, thriftStruct, 1)
+	tw.beginStruct()
+	tw.list(1, thriftStruct, len(columns))
+	for i, column := range columns {
+		tw.beginStruct()
+		tw.i64(2, chunks[i].offset)
+		tw.structField(3)
+		tw.i32(1, column.physical)
+		tw.list(2, thriftI32, 1)
+		tw.varint32(parquetEncodingPlain)
+		tw.list(3, thriftBinary, 1)
+		tw.rawBinary([]byte(column.name))
+		tw.i32(4, parquetCodecGzip)
+		tw.i64(5, int64(numRows))
+		tw.i64(6, chunks[i].uncompressedSize)
+		tw.i64(7, chunks[i].compressedSize)
+		tw.i64(9, chunks[i].offset)
+		tw.endStruct()
+		tw.endStruct()
+	}
+	tw.i64(2, totalSize)
+	tw.i64(3, int64(numRows))
+	tw.endStruct()
+
+	tw.binary(6, []byte("golog"))
+	tw.stop()
+
+	return tw.buf.Bytes()
+}
+
+// thriftWriter emits the Thrift compact protocol used by Parquet metadata.
+type thriftWriter struct {
+	buf   bytes.Buffer
+	last  int16
+	stack []int16
+}
+
+func (tw *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	tw.buf.Write(b[:n])
+}
+
+func (tw *thriftWriter) varint32(v int32) {
+	tw.uvarint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (tw *thriftWriter) varint64(v int64) {
+	tw.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (tw *thriftWriter) field(id int16, typ byte) {
+	delta := id - tw.last
+	if delta > 0 && delta <= 15 {
+		tw.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		tw.buf.WriteByte(typ)
+		tw.varint32(int32(id))
+	}
+	tw.last = id
+}
+
+func (tw *thriftWriter) i32(id int16, v int32) {
+	tw.field(id, thriftI32)
+	tw.varint32(v)
+}
+
+func (tw *thriftWriter) i64(id int16, v int64) {
+	tw.field(id, thriftI64)
+	tw.varint64(v)
+}
+
+func (tw *thriftWriter) binary(id int16, b []byte) {
+	tw.field(id, thriftBinary)
+	tw.rawBinary(b)
+}
+
+func (tw *thriftWriter) rawBinary(b []byte) {
+	tw.uvarint(uint64(len(b)))
+	tw.buf.Write(b)
+}
+
+func (tw *thriftWriter) list(id int16, elem byte, size int) {
+	tw.field(id, thriftList)
+	if size < 15 {
+		tw.buf.WriteByte(byte(size)<<4 | elem)
+	} else {
+		tw.buf.WriteByte(0xf0 | elem)
+		tw.uvarint(uint64(size))
+	}
+}
+
+func (tw *thriftWriter) structField(id int16) {
+	tw.field(id, thriftStruct)
+	tw.beginStruct()
+}
+
+func (tw *thriftWriter) beginStruct() {
+	tw.stack = append(tw.stack, tw.last)
+	tw.last = 0
+}
+
+func (tw *thriftWriter) endStruct() {
+	tw.stop()
+	tw.last = tw.stack[len(tw.stack)-1]
+	tw.stack = tw.stack[:len(tw.stack)-1]
+}
+
+func (tw *thriftWriter) stop() {
+	tw.buf.WriteByte(0)
+}
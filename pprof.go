@@ -0,0 +1,29 @@
+package golog
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// SetPprofLabels attaches the pprof labels carried by the context given to
+// the Ctx functions (as set with pprof.Do or pprof.WithLabels) as fields,
+// so logs and CPU profiles share the same request or tenant dimensions.
+// Labels on the goroutine alone are not visible to the logger.
+func (gl *GoLog) SetPprofLabels(enable bool) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.pprofLabels = enable
+	gl.publish()
+}
+
+func attachPprofLabels(ctx context.Context, entry *Entry) {
+	if ctx == nil {
+		return
+	}
+
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		entry.Fields = append(entry.Fields, Field{Key: key, Value: value})
+		return true
+	})
+}
@@ -0,0 +1,33 @@
+package golog_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestPprofLabels(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetPprofLabels(true)
+
+	pprof.Do(context.Background(), pprof.Labels("tenant", "acme"), func(ctx context.Context) {
+		golog.InfoCtx(ctx, "labelled")
+	})
+	golog.InfoCtx(context.Background(), "unlabelled")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+	if fields := sink.entries[0].Fields; len(fields) != 1 || fields[0] != (golog.Field{Key: "tenant", Value: "acme"}) {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+	if fields := sink.entries[1].Fields; len(fields) != 0 {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+}
@@ -0,0 +1,18 @@
+package golog
+
+// SetPrefix tags every entry of the logger with a component, e.g. for one
+// of several workers in a process:
+//
+//	worker := golog.Std.Named("worker")
+//	worker.SetPrefix("worker-3")
+//
+// The default header shows it in brackets before the logger name, header
+// templates as Prefix, and JSON and GCP lines as a "prefix" field. An empty
+// prefix removes it. Loggers created with Named inherit it.
+func (gl *GoLog) SetPrefix(prefix string) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.prefix = prefix
+	gl.publish()
+}
@@ -0,0 +1,37 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSetPrefix(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	worker := golog.Std.Named("worker")
+	worker.SetLevelOutput(golog.LInfo, &out)
+	worker.SetPrefix("worker-3")
+	worker.Info("started")
+
+	line := out.String()
+	if !strings.Contains(line, " [worker-3] worker (prefix_test.go:") || !strings.HasSuffix(line, "): started\n") {
+		t.Errorf("unexpected line %q", line)
+	}
+
+	entry, err := golog.NewTextReader(strings.NewReader(line)).Next()
+	if err != nil || entry.Prefix != "worker-3" || entry.Logger != "worker" || entry.Message != "started" {
+		t.Errorf("unexpected entry %+v, %v", entry, err)
+	}
+
+	out.Reset()
+	worker.SetFormat(golog.FormatJSON)
+	worker.Info("json")
+	if line := out.String(); !strings.Contains(line, `"logger":"worker","prefix":"worker-3",`) {
+		t.Errorf("unexpected line %q", line)
+	}
+}
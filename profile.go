@@ -0,0 +1,57 @@
+package golog
+
+import (
+	"time"
+)
+
+// LevelProfile replaces MinLevel while the current time matches Schedule, a
+// five-field cron expression evaluated per minute in local time. For example
+// "* 14-15 * * 2,4" with LDebug turns on debug output during a Tuesday and
+// Thursday afternoon deploy window.
+type LevelProfile struct {
+	Schedule string
+	MinLevel Level
+}
+
+type levelProfile struct {
+	schedule *cronSchedule
+	minLevel Level
+}
+
+// AddLevelProfile registers a profile. When several profiles match, the one
+// added first wins.
+func (gl *GoLog) AddLevelProfile(profile LevelProfile) error {
+	schedule, err := parseCron(profile.Schedule)
+	if err != nil {
+		return err
+	}
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.profiles = append(gl.profiles, levelProfile{schedule: schedule, minLevel: profile.MinLevel})
+	gl.publish()
+
+	return nil
+}
+
+// minLevelFor returns the minimum level in effect for entry: a file level
+// override first, then an active profile, then MinLevel.
+func (c *config) minLevelFor(entry *Entry) Level {
+	if level, ok := c.fileLevel(entry); ok {
+		return level
+	}
+
+	return c.minLevelAt(entry.Time)
+}
+
+// minLevelAt returns the minimum level in effect at t.
+func (c *config) minLevelAt(t time.Time) Level {
+	for _, profile := range c.profiles {
+		if profile.schedule.match(t) {
+			return profile.minLevel
+		}
+	}
+
+	return c.minLevel
+}
@@ -0,0 +1,36 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLevelProfile(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	if err := golog.Std.AddLevelProfile(golog.LevelProfile{Schedule: "* * * *", MinLevel: golog.LDebug}); err == nil {
+		t.Error("expected an error for a four-field schedule")
+	}
+
+	// February 31st never comes; the second window is always open.
+	if err := golog.Std.AddLevelProfile(golog.LevelProfile{Schedule: "0 0 31 2 *", MinLevel: golog.LTrace}); err != nil {
+		t.Fatal(err)
+	}
+	if err := golog.Std.AddLevelProfile(golog.LevelProfile{Schedule: "*/1 0-23 * 1-12 0-7", MinLevel: golog.LDebug}); err != nil {
+		t.Fatal(err)
+	}
+
+	golog.Trace("trace")
+	golog.Debug("debug")
+	golog.Info("info")
+
+	if got, want := sink.messages(), []string{"debug", "info"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
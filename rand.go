@@ -0,0 +1,54 @@
+package golog
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Rand is the random source behind sampling and rate limiting decisions.
+// Seeding one makes those decisions, and so the entries that get through,
+// reproducible in tests. A nil *Rand uses the global math/rand source.
+type Rand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func NewRand(seed int64) *Rand {
+	return NewRandSource(rand.NewSource(seed))
+}
+
+func NewRandSource(src rand.Source) *Rand {
+	return &Rand{r: rand.New(src)}
+}
+
+func (r *Rand) Intn(n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.r.Intn(n)
+}
+
+func (r *Rand) Float64() float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.r.Float64()
+}
+
+// SetRand sets the random source used by the logger's sampling and rate
+// limiting. nil restores the global source.
+func (gl *GoLog) SetRand(r *Rand) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.rand = r
+	gl.publish()
+}
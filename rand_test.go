@@ -0,0 +1,21 @@
+package golog_test
+
+import (
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestRand(t *testing.T) {
+	a, b := golog.NewRand(42), golog.NewRand(42)
+	for i := 0; i < 100; i++ {
+		if a.Intn(10) != b.Intn(10) || a.Float64() != b.Float64() {
+			t.Fatal("equally seeded sources diverged")
+		}
+	}
+
+	var global *golog.Rand
+	if n := global.Intn(10); n < 0 || n >= 10 {
+		t.Errorf("nil Rand returned %d", n)
+	}
+}
@@ -0,0 +1,122 @@
+package golog
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// RateLimit caps how many entries with the same message key are written per
+// Interval. The key is the format string and caller, so a loop logging
+// "retry %d failed" counts as one message whatever its arguments.
+type RateLimit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+type rateLimiter struct {
+	RateLimit
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]*rateCount
+	timer  *time.Timer
+}
+
+type rateCount struct {
+	count   int
+	level   Level
+	caller  string
+	message string
+}
+
+// SetRateLimit writes at most limit.Burst entries per message key in each
+// limit.Interval. When an interval in which entries were dropped ends,
+// "suppressed N messages like ..." is logged at their level for each key,
+// so a tight retry loop produces a handful of lines per interval instead of
+// filling the disk. A zero Interval or Burst disables the limit.
+func (gl *GoLog) SetRateLimit(limit RateLimit) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.limiter != nil {
+		gl.limiter.mu.Lock()
+		if gl.limiter.timer != nil {
+			gl.limiter.timer.Stop()
+		}
+		gl.limiter.mu.Unlock()
+	}
+
+	gl.limiter = nil
+	if limit.Interval > 0 && limit.Burst > 0 {
+		gl.limiter = &rateLimiter{RateLimit: limit}
+	}
+
+	gl.publish()
+}
+
+// rateAdmit counts entry and reports whether its key is still within the
+// limit for the current interval.
+func (gl *GoLog) rateAdmit(l *rateLimiter, entry *Entry) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// While entries are suppressed, the timer closes the interval.
+	if l.timer == nil && (l.start.IsZero() || !entry.Time.Before(l.start.Add(l.Interval))) {
+		l.start = entry.Time
+		l.counts = make(map[string]*rateCount)
+	}
+
+	key := fingerprint(entry)
+	count, ok := l.counts[key]
+	if !ok {
+		count = &rateCount{level: entry.Level, caller: entry.Caller, message: entry.Message}
+		l.counts[key] = count
+	}
+	count.count++
+	if count.count <= l.Burst {
+		return true
+	}
+
+	if l.timer == nil {
+		l.timer = time.AfterFunc(l.start.Add(l.Interval).Sub(gl.now()), func() {
+			gl.summarizeRateLimit(l)
+		})
+	}
+
+	return false
+}
+
+// summarizeRateLimit logs how many entries of each key were dropped during
+// the interval that just ended, most suppressed first.
+func (gl *GoLog) summarizeRateLimit(l *rateLimiter) {
+	l.mu.Lock()
+	counts := l.counts
+	l.counts = nil
+	l.start = time.Time{}
+	l.timer = nil
+	burst := l.Burst
+	l.mu.Unlock()
+
+	suppressed := make([]*rateCount, 0, len(counts))
+	for _, count := range counts {
+		if count.count > burst {
+			suppressed = append(suppressed, count)
+		}
+	}
+	sort.Slice(suppressed, func(i, j int) bool {
+		if suppressed[i].count != suppressed[j].count {
+			return suppressed[i].count > suppressed[j].count
+		}
+		return suppressed[i].message < suppressed[j].message
+	})
+
+	for _, count := range suppressed {
+		gl.writeInternal(count.level, sprintf("suppressed %d messages like %q (%s)",
+			[]interface{}{count.count - burst, count.message, count.caller}))
+	}
+}
@@ -0,0 +1,46 @@
+package golog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestRateLimit(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetRateLimit(golog.RateLimit{Burst: 2, Interval: 50 * time.Millisecond})
+	defer golog.Std.SetRateLimit(golog.RateLimit{})
+
+	for i := 0; i < 5; i++ {
+		golog.Error("retry %d failed", i)
+		golog.Info("tick")
+	}
+
+	want := []string{"retry 0 failed", "tick", "retry 1 failed", "tick"}
+	if messages := sink.messages(); strings.Join(messages, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %q, got %q", want, messages)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	messages := sink.messages()[len(want):]
+	if len(messages) != 2 ||
+		!strings.HasPrefix(messages[0], `suppressed 3 messages like "retry 0 failed" (ratelimit_test.go:`) ||
+		!strings.HasPrefix(messages[1], `suppressed 3 messages like "tick"`) {
+		t.Fatalf("unexpected summaries %q", messages)
+	}
+	if sink.entries[len(want)].Level != golog.LError {
+		t.Errorf("expected the summary at the original level, got %v", sink.entries[len(want)].Level)
+	}
+
+	golog.Error("retry %d failed", 9)
+	if messages := sink.messages(); messages[len(messages)-1] != "retry 9 failed" {
+		t.Errorf("limit did not reset: %q", messages)
+	}
+}
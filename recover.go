@@ -0,0 +1,105 @@
+package golog
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+var packagePrefix = reflect.TypeOf(GoLog{}).PkgPath() + "."
+
+// RecoverAndLog recovers from a panic and logs it at LPanic instead of
+// letting it crash the program. Defer it at the top of goroutines:
+//
+//	defer golog.RecoverAndLog()
+//
+// The entry is attributed to the panicking function, the panic value is
+// described by kind (error chain, runtime error, Stringer) and the
+// panicking goroutine's stack is attached as "stacktrace", starting at the
+// panic and without golog's own frames. The exit hooks run for it as for
+// Panic, but the program carries on.
+func RecoverAndLog() {
+	if r := recover(); r != nil {
+		getCurrentLogger().logPanic(r)
+	}
+}
+
+func (gl *GoLog) logPanic(r interface{}) {
+	c := gl.config()
+	frames := panicFrames()
+
+	entry := gl.newEntryAt(0, LPanic, "%s", []interface{}{"panic: " + describePanic(r)})
+	entry.Fields = []Field{{Key: "panic_type", Value: fmt.Sprintf("%T", r)}}
+
+	if len(frames) > 0 {
+		setCallerFrame(entry, frames[0])
+		c.formatCaller(entry)
+
+		var stack strings.Builder
+		for i, frame := range frames {
+			if i > 0 {
+				stack.WriteByte('\n')
+			}
+			fmt.Fprintf(&stack, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+		entry.Fields = append(entry.Fields, Field{Key: "stacktrace", Value: stack.String()})
+	}
+
+	gl.write(entry)
+
+	// As for Fatal, the exit hooks see the entry before the buffers are
+	// flushed.
+	entry.render()
+	gl.runExitHooks(entry)
+	gl.Flush()
+}
+
+// describePanic renders a panic value: errors with their wrapped causes,
+// runtime errors as is, Stringers through String and anything else with
+// its type.
+func describePanic(r interface{}) string {
+	switch v := r.(type) {
+	case runtime.Error:
+		return v.Error()
+	case error:
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s (%T)", v.Error(), v)
+		for cause := unwrapError(v); cause != nil; cause = unwrapError(cause) {
+			fmt.Fprintf(&b, "\n\tcaused by: %s (%T)", cause.Error(), cause)
+		}
+		return b.String()
+	case fmt.Stringer:
+		return v.String()
+	case string:
+		return v
+	}
+
+	return fmt.Sprintf("%v (%T)", r, r)
+}
+
+// panicFrames returns the stack of the panicking goroutine from the frame
+// that panicked outwards, leaving out golog and the runtime.
+func panicFrames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	var frames []runtime.Frame
+	it := runtime.CallersFrames(pcs)
+	for {
+		frame, more := it.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			// Everything so far was the deferred call chain.
+			frames = frames[:0]
+		case !strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasPrefix(frame.Function, "runtime."):
+			frames = append(frames, frame)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
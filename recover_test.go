@@ -0,0 +1,97 @@
+package golog_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func panicWith(v interface{}) {
+	defer golog.RecoverAndLog()
+	panic(v)
+}
+
+func indexOutOfRange(i int) {
+	defer golog.RecoverAndLog()
+	_ = []int{}[i]
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	panicWith(fmt.Errorf("loading config: %w", errors.New("file not found")))
+	indexOutOfRange(3)
+	panicWith(42)
+
+	if len(sink.entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(sink.entries))
+	}
+
+	entry := sink.entries[0]
+	if entry.Level != golog.LPanic ||
+		entry.Message != "panic: loading config: file not found (*fmt.wrapError)\n\tcaused by: file not found (*errors.errorString)" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.Caller != "recover_test.go:14" {
+		t.Errorf("expected the panicking line as caller, got %s", entry.Caller)
+	}
+
+	var stack string
+	for _, field := range entry.Fields {
+		if field.Key == "stacktrace" {
+			stack = field.Value.(string)
+		}
+	}
+	if !strings.HasPrefix(stack, "github.com/miyaizu/golog_test.panicWith\n") ||
+		!strings.Contains(stack, "golog_test.TestRecoverAndLog") ||
+		strings.Contains(stack, "golog.RecoverAndLog") || strings.Contains(stack, "runtime.gopanic") {
+		t.Errorf("unexpected stack:\n%s", stack)
+	}
+
+	if msg := sink.entries[1].Message; !strings.HasPrefix(msg, "panic: runtime error: index out of range [3]") {
+		t.Errorf("unexpected runtime error message %q", msg)
+	}
+	if msg := sink.entries[2].Message; msg != "panic: 42 (int)" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestRecoverAndLogEntry(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+	defer golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetPrefix("worker")
+	golog.Std.SetGoroutineIDs(true)
+	golog.Std.SetEntryIDs(golog.IDULID)
+
+	var hooked []string
+	golog.Std.AddExitHook(func(entry golog.Entry) {
+		hooked = append(hooked, entry.Message)
+	})
+
+	panicWith("100% broken")
+
+	if len(sink.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(sink.entries))
+	}
+	entry := sink.entries[0]
+	if entry.Prefix != "worker" || entry.Goroutine == 0 || entry.ID == "" {
+		t.Errorf("entry misses prefix, goroutine or id: %+v", entry)
+	}
+	if entry.Message != "panic: 100% broken" {
+		t.Errorf("unexpected message %q", entry.Message)
+	}
+	if len(hooked) != 1 || hooked[0] != entry.Message {
+		t.Errorf("exit hooks not run for the entry: %q", hooked)
+	}
+}
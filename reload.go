@@ -0,0 +1,130 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// WatchOption configures WatchConfig.
+type WatchOption struct {
+	// Signals trigger a reload; SIGHUP when empty.
+	Signals []os.Signal
+
+	// Interval is how often the file is checked for changes to its size or
+	// modification time; 2s when 0. A negative Interval reloads on signals
+	// only.
+	Interval time.Duration
+}
+
+// WatchConfig reloads the config file at path, read as SetupFromFile reads
+// it, whenever a signal arrives or the file changes, so verbosity can be
+// raised on a live process without a restart:
+//
+//	golog.SetupFromFile("logging.yaml")
+//	stop := golog.WatchConfig("logging.yaml", nil)
+//	defer stop()
+//
+// With an empty path, the environment variables read by SetupLogger(nil)
+// are evaluated again instead. A reload changes the existing loggers in
+// place, each switching to the new levels, format and file sinks at once,
+// and closes the file sinks of the previous config. Reloads are logged at
+// LNotice; an invalid config is logged at LError and the current one kept.
+// No reload runs once stop has returned.
+func WatchConfig(path string, option *WatchOption) (stop func()) {
+	if option == nil {
+		option = &WatchOption{}
+	}
+	sigs := option.Signals
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+	interval := option.Interval
+	if interval == 0 {
+		interval = 2 * time.Second
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	var stamp fileStamp
+	if path != "" && interval > 0 {
+		ticker = time.NewTicker(interval)
+		tick = ticker.C
+		stamp, _ = statStamp(path)
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-ch:
+				reloadConfig(path)
+			case <-tick:
+				if current, ok := statStamp(path); ok && current != stamp {
+					stamp = current
+					reloadConfig(path)
+				}
+			case <-done:
+				if ticker != nil {
+					ticker.Stop()
+				}
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+			<-exited
+		})
+	}
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func statStamp(path string) (fileStamp, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, false
+	}
+
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}, true
+}
+
+func reloadConfig(path string) {
+	if path == "" {
+		option := &GoLogOption{Colorize: true, MinLevel: LDebug}
+		rc := &resolvedConfig{option: option}
+		rc.output = optionFromEnv(option, GetCurrentOutput())
+		rc.apply()
+		getCurrentLogger().writeInternal(LNotice, "reloaded logging config from the environment")
+		return
+	}
+
+	cfg, err := readConfigFile(path)
+	var rc *resolvedConfig
+	if err == nil {
+		rc, err = resolveConfig(cfg)
+	}
+	if err != nil {
+		getCurrentLogger().writeInternal(LError, fmt.Sprintf("logging config not reloaded: %v", err))
+		return
+	}
+
+	rc.apply()
+	getCurrentLogger().writeInternal(LNotice, "reloaded logging config from "+path)
+}
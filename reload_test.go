@@ -0,0 +1,81 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package golog_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func waitFor(cond func() bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	return true
+}
+
+func TestWatchConfig(t *testing.T) {
+	defer golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	defer golog.SetOutput(golog.OStdout)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	os.WriteFile(path, []byte("level: info\noutput: stdout\nloggers:\n  reload.db:\n    level: warn\n"), 0o644)
+	if err := golog.SetupFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	db := golog.GetLogger("reload.db")
+
+	stop := golog.WatchConfig(path, &golog.WatchOption{Signals: []os.Signal{syscall.SIGUSR2}, Interval: -1})
+	defer stop()
+
+	// The file is only read on the signal.
+	logPath := filepath.ToSlash(filepath.Join(dir, "app.log"))
+	os.WriteFile(path, []byte("level: debug\noutput: stdout\nfiles:\n  - path: "+logPath+"\nloggers:\n  reload.db:\n    level: trace\n"), 0o644)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	if !waitFor(func() bool { return golog.Std.Enabled(golog.LDebug) && db.Enabled(golog.LTrace) }) {
+		t.Fatal("config not reloaded")
+	}
+
+	db.Trace("after reload")
+	golog.Std.Flush()
+	if !waitFor(func() bool { data, _ := os.ReadFile(logPath); return len(data) > 0 }) {
+		t.Error("the reloaded file sink received nothing")
+	}
+
+	// An invalid config keeps the current one.
+	os.WriteFile(path, []byte("level: loud\n"), 0o644)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	time.Sleep(50 * time.Millisecond)
+	if !golog.Std.Enabled(golog.LDebug) {
+		t.Error("invalid config applied")
+	}
+}
+
+func TestWatchConfigFileChange(t *testing.T) {
+	defer golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+
+	path := filepath.Join(t.TempDir(), "logging.json")
+	os.WriteFile(path, []byte(`{"level": "info", "output": "stdout"}`), 0o644)
+	if err := golog.SetupFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := golog.WatchConfig(path, &golog.WatchOption{Interval: 5 * time.Millisecond})
+	defer stop()
+
+	os.WriteFile(path, []byte(`{"level": "error", "output": "stdout"}`), 0o644)
+	if !waitFor(func() bool { return !golog.Std.Enabled(golog.LWarning) }) {
+		t.Error("file change not picked up")
+	}
+}
@@ -0,0 +1,90 @@
+package golog
+
+import (
+	"sync"
+	"time"
+)
+
+type repeatFilter struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	last  *Entry
+	count int
+	timer *time.Timer
+}
+
+// SetRepeatWindow collapses consecutive identical entries, as syslog does:
+// an entry with the same level, caller and message as the one before it is
+// dropped and counted, and "last message repeated N times" is logged when a
+// different entry arrives or window after the first repeat, whichever comes
+// first. A zero window disables it.
+func (gl *GoLog) SetRepeatWindow(window time.Duration) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.repeats != nil {
+		gl.repeats.mu.Lock()
+		if gl.repeats.timer != nil {
+			gl.repeats.timer.Stop()
+		}
+		gl.repeats.mu.Unlock()
+	}
+
+	gl.repeats = nil
+	if window > 0 {
+		gl.repeats = &repeatFilter{window: window}
+	}
+
+	gl.publish()
+}
+
+// repeatAdmit reports whether entry differs from the previous one. The
+// pending repeat count is logged before the first entry that differs.
+func (gl *GoLog) repeatAdmit(r *repeatFilter, entry *Entry) bool {
+	if r == nil {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if last := r.last; last != nil &&
+		last.Level == entry.Level && last.Caller == entry.Caller && last.Message == entry.Message {
+		r.count++
+		if r.timer == nil {
+			var timer *time.Timer
+			timer = time.AfterFunc(r.window, func() {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+
+				// A different entry may have flushed the count meanwhile.
+				if r.timer == timer {
+					gl.flushRepeats(r)
+					r.last = nil
+				}
+			})
+			r.timer = timer
+		}
+		return false
+	}
+
+	gl.flushRepeats(r)
+	r.last = entry
+
+	return true
+}
+
+// flushRepeats logs the pending repeat count; r.mu must be held.
+func (gl *GoLog) flushRepeats(r *repeatFilter) {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	if r.count == 0 {
+		return
+	}
+
+	gl.writeInternal(r.last.Level, sprintf("last message repeated %d times", []interface{}{r.count}))
+	r.count = 0
+}
@@ -0,0 +1,45 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestRepeatWindow(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetRepeatWindow(50 * time.Millisecond)
+	defer golog.Std.SetRepeatWindow(0)
+
+	up := func() { golog.Info("link up") }
+	for i := 0; i < 4; i++ {
+		golog.Warn("link down")
+	}
+	up()
+	up()
+
+	want := []string{"link down", "last message repeated 3 times", "link up"}
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	sink.mu.Lock()
+	level := sink.entries[1].Level
+	sink.mu.Unlock()
+	if level != golog.LWarning {
+		t.Errorf("expected the repeat count at the original level, got %v", level)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	up()
+	want = append(want, "last message repeated 1 times", "link up")
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
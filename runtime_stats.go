@@ -0,0 +1,94 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// LogRuntimeStats logs the number of goroutines, heap usage, garbage
+// collections and open file descriptors at level every interval, until the
+// returned function is called. GC figures cover the time since the previous
+// report. Each figure is also attached as a field.
+func (gl *GoLog) LogRuntimeStats(interval time.Duration, level Level) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var prev runtime.MemStats
+		runtime.ReadMemStats(&prev)
+
+		for {
+			select {
+			case <-ticker.C:
+				var stats runtime.MemStats
+				runtime.ReadMemStats(&stats)
+				gl.logRuntimeStats(level, &prev, &stats)
+				prev = stats
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+func (gl *GoLog) logRuntimeStats(level Level, prev, stats *runtime.MemStats) {
+	gcs := stats.NumGC - prev.NumGC
+	pauseTotal := time.Duration(stats.PauseTotalNs - prev.PauseTotalNs)
+
+	var pauseMax time.Duration
+	for i := uint32(0); i < gcs && i < uint32(len(stats.PauseNs)); i++ {
+		pause := time.Duration(stats.PauseNs[(stats.NumGC-i+255)%256])
+		if pause > pauseMax {
+			pauseMax = pause
+		}
+	}
+
+	c := gl.config()
+	entry := &Entry{
+		Level:  level,
+		Time:   c.now(),
+		Caller: "golog",
+		Fields: []Field{
+			{Key: "goroutines", Value: runtime.NumGoroutine()},
+			{Key: "heap_alloc", Value: stats.HeapAlloc},
+			{Key: "heap_sys", Value: stats.HeapSys},
+			{Key: "heap_objects", Value: stats.HeapObjects},
+			{Key: "gc_count", Value: gcs},
+			{Key: "gc_pause_total", Value: pauseTotal.String()},
+			{Key: "gc_pause_max", Value: pauseMax.String()},
+		},
+	}
+	entry.Message = fmt.Sprintf("runtime: goroutines=%d heap=%.1fMiB/%.1fMiB objects=%d gc=%d pause=%s (max %s)",
+		runtime.NumGoroutine(), float64(stats.HeapAlloc)/(1<<20), float64(stats.HeapSys)/(1<<20),
+		stats.HeapObjects, gcs, pauseTotal, pauseMax)
+
+	if fds := openFDs(); fds >= 0 {
+		entry.Fields = append(entry.Fields, Field{Key: "open_fds", Value: fds})
+		entry.Message += fmt.Sprintf(" fds=%d", fds)
+	}
+
+	gl.emit(c, entry, c.minLevelAt(entry.Time))
+}
+
+// openFDs counts the process's open file descriptors, or returns -1 where
+// the platform offers no cheap way to do so.
+func openFDs() int {
+	for _, dir := range []string{"/proc/self/fd", "/dev/fd"} {
+		if entries, err := os.ReadDir(dir); err == nil {
+			// Reading the directory itself holds one descriptor.
+			return len(entries) - 1
+		}
+	}
+
+	return -1
+}
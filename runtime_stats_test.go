@@ -0,0 +1,39 @@
+package golog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestLogRuntimeStats(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	stop := golog.Std.LogRuntimeStats(10*time.Millisecond, golog.LInfo)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.messages()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	stop()
+
+	messages := sink.messages()
+	if len(messages) == 0 {
+		t.Fatal("no runtime stats were logged")
+	}
+	if !strings.HasPrefix(messages[0], "runtime: goroutines=") {
+		t.Errorf("unexpected message %q", messages[0])
+	}
+
+	sink.mu.Lock()
+	fields := sink.entries[0].Fields
+	sink.mu.Unlock()
+	if len(fields) == 0 || fields[0].Key != "goroutines" || fields[0].Value.(int) < 1 {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+}
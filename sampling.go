@@ -0,0 +1,59 @@
+package golog
+
+import "sync/atomic"
+
+// Sampling keeps one in Rate entries of a level. By default every Rate-th
+// entry is kept; Random keeps each entry with probability 1/Rate instead,
+// using the logger's Rand, which avoids lockstep with periodic callers.
+type Sampling struct {
+	Rate   int
+	Random bool
+}
+
+type sampler struct {
+	Sampling
+	seen uint64
+}
+
+// SetSampling samples the entries of exactly level, e.g. one in 100 Debug
+// entries while Warnings and above are all kept. Kept entries carry the
+// rate as a "sample_rate" field so dashboards can scale counts back up. A
+// Rate of 1 or less stops sampling level.
+func (gl *GoLog) SetSampling(level Level, sampling Sampling) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	samplers := make(map[Level]*sampler, len(gl.samplers)+1)
+	for l, s := range gl.samplers {
+		samplers[l] = s
+	}
+	if sampling.Rate > 1 {
+		samplers[level] = &sampler{Sampling: sampling}
+	} else {
+		delete(samplers, level)
+	}
+
+	gl.samplers = samplers
+	gl.publish()
+}
+
+// sampled reports whether entry is kept by the sampler for its level and
+// tags kept entries with the rate.
+func (c *config) sampled(entry *Entry) bool {
+	s, ok := c.samplers[entry.Level]
+	if !ok {
+		return true
+	}
+
+	if s.Random {
+		if c.rand.Intn(s.Rate) != 0 {
+			return false
+		}
+	} else if (atomic.AddUint64(&s.seen, 1)-1)%uint64(s.Rate) != 0 {
+		return false
+	}
+
+	entry.Fields = append(entry.Fields, Field{Key: "sample_rate", Value: s.Rate})
+
+	return true
+}
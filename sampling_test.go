@@ -0,0 +1,59 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSampling(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+	golog.Std.SetSampling(golog.LDebug, golog.Sampling{Rate: 3})
+
+	for i := 0; i < 7; i++ {
+		golog.Debug("debug %d", i)
+	}
+	golog.Warn("warn")
+
+	want := []string{"debug 0", "debug 3", "debug 6", "warn"}
+	if got := sink.messages(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if fields := sink.entries[0].Fields; !reflect.DeepEqual(fields, []golog.Field{{Key: "sample_rate", Value: 3}}) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if fields := sink.entries[3].Fields; len(fields) != 0 {
+		t.Errorf("unsampled entry got fields %v", fields)
+	}
+}
+
+func TestRandomSampling(t *testing.T) {
+	run := func() []string {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+		golog.SetOutput(golog.OStdout)
+
+		sink := &memorySink{}
+		golog.Std.AddSink(sink)
+		golog.Std.SetRand(golog.NewRand(1))
+		golog.Std.SetSampling(golog.LDebug, golog.Sampling{Rate: 4, Random: true})
+
+		for i := 0; i < 400; i++ {
+			golog.Debug("debug %d", i)
+		}
+
+		return sink.messages()
+	}
+
+	first := run()
+	if len(first) < 50 || len(first) > 150 {
+		t.Errorf("expected about 100 of 400 entries, got %d", len(first))
+	}
+	if second := run(); !reflect.DeepEqual(first, second) {
+		t.Error("equally seeded sampling kept different entries")
+	}
+}
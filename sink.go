@@ -0,0 +1,95 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+)
+
+// Sink receives every entry written by a GoLog in addition to its own output.
+type Sink interface {
+	Write(entry *Entry) error
+	Close() error
+}
+
+type sinkEntry struct {
+	sink     Sink
+	minLevel Level
+}
+
+func (se sinkEntry) admits(level, loggerMin Level) bool {
+	if se.minLevel == unknownLevel {
+		return level >= loggerMin
+	}
+
+	return level >= se.minLevel
+}
+
+// sinksAdmit reports whether any sink takes an entry at level.
+func (c *config) sinksAdmit(level, loggerMin Level) bool {
+	for _, se := range c.sinks {
+		if se.admits(level, loggerMin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddSink attaches a sink that follows the logger's MinLevel.
+func (gl *GoLog) AddSink(sink Sink) {
+	gl.AddSinkLevel(sink, unknownLevel)
+}
+
+// AddSinkLevel attaches a sink with its own minimum level, independent of
+// MinLevel. A sink at LTrace next to a console at LInfo keeps full detail
+// for postmortems without cluttering the terminal.
+func (gl *GoLog) AddSinkLevel(sink Sink, level Level) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.sinks = append(gl.sinks, sinkEntry{sink: sink, minLevel: level})
+	gl.publish()
+}
+
+func (gl *GoLog) Close() error {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.async != nil {
+		gl.async.stop()
+		gl.async = nil
+	}
+
+	var firstErr error
+	if gl.buffer != nil {
+		firstErr = gl.buffer.stop()
+		gl.buffer = nil
+	}
+	for _, se := range gl.sinks {
+		if err := se.sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	gl.sinks = nil
+	gl.publish()
+
+	return firstErr
+}
+
+// writeSinks hands entry to every sink whose level admits it; sinks without
+// a level of their own use loggerMin.
+func (c *config) writeSinks(entry *Entry, loggerMin Level) {
+	for _, se := range c.sinks {
+		if !se.admits(entry.Level, loggerMin) {
+			continue
+		}
+
+		if err := se.sink.Write(entry); err != nil {
+			reportSinkError(err)
+		}
+	}
+}
+
+func reportSinkError(err error) {
+	fmt.Fprintf(os.Stderr, "golog: sink write failed: %v\n", err)
+}
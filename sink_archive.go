@@ -0,0 +1,331 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const defaultArchiveKeyTemplate = "{{.Date}}/{{.Hostname}}-{{.PID}}-{{.Timestamp}}.ndjson.gz"
+
+type ArchiveSinkOption struct {
+	Store BlobStore
+
+	// Dir holds chunks until they are uploaded. Chunks left over from a
+	// previous run are uploaded on start.
+	Dir string
+
+	// KeyTemplate renders the object key from ArchiveKeyParam.
+	KeyTemplate string
+
+	MaxChunkSize   int64
+	UploadInterval time.Duration
+	MaxRetries     int
+}
+
+type ArchiveKeyParam struct {
+	Time      time.Time
+	Date      string
+	Hour      string
+	Timestamp int64
+	Hostname  string
+	PID       int
+	Seq       uint64
+}
+
+// ArchiveSink writes entries as gzip-compressed NDJSON chunks to a local
+// directory and uploads each chunk to a BlobStore once it is large or old
+// enough.
+type ArchiveSink struct {
+	store        BlobStore
+	dir          string
+	keyTemplate  *template.Template
+	maxChunkSize int64
+	maxRetries   int
+	hostname     string
+
+	mu     sync.Mutex
+	file   *os.File
+	gz     *gzip.Writer
+	size   int64
+	opened time.Time
+
+	uploadMu sync.Mutex
+	seq      uint64
+
+	kick chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewArchiveSink(option *ArchiveSinkOption) (*ArchiveSink, error) {
+	if option == nil || option.Store == nil {
+		return nil, errors.New("golog: archive sink requires a blob store")
+	}
+
+	keyTemplate := option.KeyTemplate
+	if keyTemplate == "" {
+		keyTemplate = defaultArchiveKeyTemplate
+	}
+	tmpl, err := template.New("GoLogArchiveKeyTemplate").Parse(keyTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := option.Dir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "golog-archive")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &ArchiveSink{
+		store:        option.Store,
+		dir:          dir,
+		keyTemplate:  tmpl,
+		maxChunkSize: option.MaxChunkSize,
+		maxRetries:   option.MaxRetries,
+		kick:         make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+	if s.maxChunkSize <= 0 {
+		s.maxChunkSize = 16 << 20
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	s.hostname, _ = os.Hostname()
+
+	if err := s.recoverChunks(); err != nil {
+		return nil, err
+	}
+
+	interval := option.UploadInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	s.wg.Add(1)
+	go s.uploadLoop(interval)
+
+	return s, nil
+}
+
+func (s *ArchiveSink) uploadLoop(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				reportSinkError(err)
+			}
+		case <-s.kick:
+			if err := s.uploadPending(); err != nil {
+				reportSinkError(err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *ArchiveSink) Write(entry *Entry) error {
+	line, err := json.Marshal(entry.values())
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	if s.file == nil {
+		if err := s.openChunk(); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	_, err = s.gz.Write(line)
+	s.size += int64(len(line))
+	full := s.size >= s.maxChunkSize
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if full {
+		if err := s.closeChunk(); err != nil {
+			return err
+		}
+
+		select {
+		case s.kick <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Flush closes the current chunk and uploads every pending chunk.
+func (s *ArchiveSink) Flush() error {
+	if err := s.closeChunk(); err != nil {
+		return err
+	}
+
+	return s.uploadPending()
+}
+
+func (s *ArchiveSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+
+	return s.Flush()
+}
+
+func (s *ArchiveSink) openChunk() error {
+	s.opened = time.Now()
+	file, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("%d.ndjson.gz.open", s.opened.UnixNano())))
+	if err != nil {
+		return err
+	}
+
+	s.file = file
+	s.gz = gzip.NewWriter(file)
+	s.size = 0
+
+	return nil
+}
+
+func (s *ArchiveSink) closeChunk() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	file := s.file
+	s.file = nil
+
+	if err := s.gz.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), strings.TrimSuffix(file.Name(), ".open"))
+}
+
+// recoverChunks salvages chunks that were still open when a previous
+// process died; their gzip trailer is missing, so the readable part is
+// recompressed into a complete chunk.
+func (s *ArchiveSink) recoverChunks() error {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.ndjson.gz.open"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var plain bytes.Buffer
+		if zr, err := gzip.NewReader(bytes.NewReader(data)); err == nil {
+			io.Copy(&plain, zr)
+		}
+
+		if plain.Len() > 0 {
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			zw.Write(plain.Bytes())
+			zw.Close()
+
+			if err := os.WriteFile(strings.TrimSuffix(path, ".open"), buf.Bytes(), 0644); err != nil {
+				return err
+			}
+		}
+
+		os.Remove(path)
+	}
+
+	return nil
+}
+
+func (s *ArchiveSink) uploadPending() error {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.ndjson.gz"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		nano, err := strconv.ParseInt(strings.TrimSuffix(filepath.Base(path), ".ndjson.gz"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		s.seq++
+		key, err := s.objectKey(time.Unix(0, nano), s.seq)
+		if err != nil {
+			return err
+		}
+
+		err = retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+			return true, s.store.Put(key, data)
+		})
+		if err != nil {
+			return err
+		}
+
+		os.Remove(path)
+	}
+
+	return nil
+}
+
+func (s *ArchiveSink) objectKey(t time.Time, seq uint64) (string, error) {
+	utc := t.UTC()
+	param := ArchiveKeyParam{
+		Time:      utc,
+		Date:      utc.Format("2006-01-02"),
+		Hour:      utc.Format("15"),
+		Timestamp: t.UnixNano(),
+		Hostname:  s.hostname,
+		PID:       os.Getpid(),
+		Seq:       seq,
+	}
+
+	var buf bytes.Buffer
+	if err := s.keyTemplate.Execute(&buf, param); err != nil {
+		return "", err
+	}
+
+	return strings.TrimLeft(buf.String(), "/"), nil
+}
@@ -0,0 +1,104 @@
+package golog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+type memoryBlobStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memoryBlobStore) Put(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = data
+	return nil
+}
+
+func TestArchiveSink(t *testing.T) {
+	store := &memoryBlobStore{objects: map[string][]byte{}}
+
+	sink, err := golog.NewArchiveSink(&golog.ArchiveSinkOption{
+		Store:       store,
+		Dir:         t.TempDir(),
+		KeyTemplate: "app/dt={{.Date}}/{{.Seq}}.ndjson.gz",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Message: "first"})
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Message: "second"})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	key := "app/dt=" + now.UTC().Format("2006-01-02") + "/1.ndjson.gz"
+	data, ok := store.objects[key]
+	if !ok {
+		t.Fatalf("object %s not uploaded", key)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := io.ReadAll(zr)
+	if lines := strings.Split(strings.TrimSpace(string(plain)), "\n"); len(lines) != 2 {
+		t.Errorf("expected 2 lines, got %q", plain)
+	}
+}
+
+func TestBlobStores(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.RequestURI())
+	}))
+	defer srv.Close()
+
+	s3, _ := golog.NewS3Store(&golog.S3StoreOption{
+		Bucket:      "logs",
+		Credentials: golog.AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		Endpoint:    srv.URL,
+	})
+	gcs, _ := golog.NewGCSStore(&golog.GCSStoreOption{
+		Bucket:      "logs",
+		TokenSource: func() (string, error) { return "token", nil },
+		Endpoint:    srv.URL,
+	})
+	azure, _ := golog.NewAzureBlobStore(&golog.AzureBlobStoreOption{
+		Container: "logs",
+		SASToken:  "?sv=1&sig=x",
+		Endpoint:  srv.URL,
+	})
+
+	for _, store := range []golog.BlobStore{s3, gcs, azure} {
+		if err := store.Put("dt=2018-07-01/1.ndjson.gz", []byte("data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{
+		"PUT /logs/dt%3D2018-07-01/1.ndjson.gz",
+		"POST /upload/storage/v1/b/logs/o?uploadType=media&name=dt%3D2018-07-01%2F1.ndjson.gz",
+		"PUT /logs/dt%3D2018-07-01/1.ndjson.gz?sv=1&sig=x",
+	}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Errorf("request %d: got %v, want %q", i, got, want[i])
+		}
+	}
+}
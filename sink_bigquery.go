@@ -0,0 +1,225 @@
+package golog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const bigQueryEndpoint = "https://bigquery.googleapis.com/bigquery/v2"
+
+type BigQuerySinkOption struct {
+	Project string
+	Dataset string
+	Table   string
+
+	// TokenSource returns an OAuth2 access token for the insertAll call.
+	TokenSource func() (string, error)
+
+	// FieldColumns maps structured field keys to table columns. When nil,
+	// every field is sent under its own key.
+	FieldColumns map[string]string
+
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// BigQuerySink streams entries into a BigQuery table with the insertAll API.
+// Rows carry time, level, caller and message columns plus mapped fields.
+type BigQuerySink struct {
+	url          string
+	tokenSource  func() (string, error)
+	fieldColumns map[string]string
+	maxRetries   int
+	client       *http.Client
+	batch        *batcher
+}
+
+type bigQueryRow struct {
+	InsertID string                 `json:"insertId"`
+	JSON     map[string]interface{} `json:"json"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+type bigQueryErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+func NewBigQuerySink(option *BigQuerySinkOption) (*BigQuerySink, error) {
+	if option == nil || option.Project == "" || option.Dataset == "" || option.Table == "" {
+		return nil, errors.New("golog: bigquery sink requires project, dataset and table")
+	}
+	if option.TokenSource == nil {
+		return nil, errors.New("golog: bigquery sink requires a token source")
+	}
+
+	endpoint := option.Endpoint
+	if endpoint == "" {
+		endpoint = bigQueryEndpoint
+	}
+
+	s := &BigQuerySink{
+		url: fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll",
+			strings.TrimRight(endpoint, "/"), option.Project, option.Dataset, option.Table),
+		tokenSource:  option.TokenSource,
+		fieldColumns: option.FieldColumns,
+		maxRetries:   option.MaxRetries,
+		client:       option.HTTPClient,
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 500
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	s.batch = newBatcher("bigquery sink", maxBatch, interval, s.insert)
+
+	return s, nil
+}
+
+func (s *BigQuerySink) Write(entry *Entry) error {
+	return s.batch.add(entry)
+}
+
+func (s *BigQuerySink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *BigQuerySink) Close() error {
+	return s.batch.close()
+}
+
+func (s *BigQuerySink) row(entry *Entry) bigQueryRow {
+	values := map[string]interface{}{
+		"time":    entry.Time.UTC().Format(time.RFC3339Nano),
+		"level":   trimLevel(entry.Level),
+		"caller":  entry.Caller,
+		"message": entry.Message,
+	}
+
+	for _, field := range entry.Fields {
+		column := field.Key
+		if s.fieldColumns != nil {
+			var ok bool
+			if column, ok = s.fieldColumns[field.Key]; !ok {
+				continue
+			}
+		}
+		values[column] = field.Value
+	}
+
+	return bigQueryRow{InsertID: newInsertID(), JSON: values}
+}
+
+func (s *BigQuerySink) insert(entries []Entry) error {
+	rows := make([]bigQueryRow, len(entries))
+	for i := range entries {
+		rows[i] = s.row(&entries[i])
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"kind": "bigquery#tableDataInsertAllRequest",
+		"rows": rows,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Insert IDs stay the same across retries so BigQuery can deduplicate.
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		return s.post(body)
+	})
+}
+
+func (s *BigQuerySink) post(body []byte) (bool, error) {
+	token, err := s.tokenSource()
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp bigQueryErrorResponse
+		json.Unmarshal(data, &errResp)
+
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		for _, e := range errResp.Error.Errors {
+			if e.Reason == "quotaExceeded" || e.Reason == "rateLimitExceeded" || e.Reason == "backendError" {
+				retry = true
+			}
+		}
+
+		return retry, fmt.Errorf("golog: bigquery insert failed: %s: %s", resp.Status, errResp.Error.Message)
+	}
+
+	var insertResp bigQueryInsertResponse
+	if err := json.Unmarshal(data, &insertResp); err != nil {
+		return false, err
+	}
+	if len(insertResp.InsertErrors) > 0 {
+		first := insertResp.InsertErrors[0]
+		msg := ""
+		if len(first.Errors) > 0 {
+			msg = first.Errors[0].Reason + ": " + first.Errors[0].Message
+		}
+		return false, fmt.Errorf("golog: bigquery rejected %d rows (row %d: %s)",
+			len(insertResp.InsertErrors), first.Index, msg)
+	}
+
+	return false, nil
+}
+
+func newInsertID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
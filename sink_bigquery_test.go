@@ -0,0 +1,74 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestBigQuerySink(t *testing.T) {
+	var calls int
+	var rows []map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/projects/p/datasets/d/tables/logs/insertAll" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+
+		if calls == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"quota","errors":[{"reason":"quotaExceeded"}]}}`))
+			return
+		}
+
+		var req struct {
+			Rows []struct {
+				JSON map[string]interface{} `json:"json"`
+			} `json:"rows"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, row := range req.Rows {
+			rows = append(rows, row.JSON)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewBigQuerySink(&golog.BigQuerySinkOption{
+		Project:      "p",
+		Dataset:      "d",
+		Table:        "logs",
+		TokenSource:  func() (string, error) { return "token", nil },
+		FieldColumns: map[string]string{"user": "user_id"},
+		Endpoint:     srv.URL,
+		MaxRetries:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink.Write(&golog.Entry{
+		Level:   golog.LWarning,
+		Time:    time.Now(),
+		Message: "test",
+		Fields:  []golog.Field{{Key: "user", Value: "u1"}, {Key: "skip", Value: 1}},
+	})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 || len(rows) != 1 {
+		t.Fatalf("expected 1 row after retry, got %d rows in %d calls", len(rows), calls)
+	}
+	if rows[0]["level"] != "warn" || rows[0]["user_id"] != "u1" || rows[0]["skip"] != nil {
+		t.Errorf("unexpected row %v", rows[0])
+	}
+}
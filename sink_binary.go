@@ -0,0 +1,108 @@
+package golog
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+type BinarySinkOption struct {
+	Path string
+}
+
+// BinarySink appends entries to a file in the compact binary log format.
+// Read it back with NewBinaryReader.
+type BinarySink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *binlogEncoder
+	buf  []byte
+}
+
+func NewBinarySink(option *BinarySinkOption) (*BinarySink, error) {
+	if option == nil || option.Path == "" {
+		return nil, errors.New("golog: binary sink requires a path")
+	}
+
+	file, err := os.OpenFile(option.Path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BinarySink{file: file, enc: newBinlogEncoder()}
+	if err := s.resume(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// resume rebuilds the dictionary from an existing log so new records can
+// keep referring to it, and cuts off a record torn by an earlier crash.
+func (s *BinarySink) resume() error {
+	info, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		_, err := s.file.WriteString(binlogMagic)
+		return err
+	}
+
+	br, err := NewBinaryReader(s.file)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err = br.Next(); err != nil {
+			break
+		}
+	}
+	if err != io.EOF && err != io.ErrUnexpectedEOF && err != ErrBinlogCorrupt {
+		return err
+	}
+
+	for _, str := range br.dict {
+		s.enc.add(str)
+	}
+	s.enc.lastTime = br.lastTime
+	s.enc.v1 = br.v1
+
+	if err := s.file.Truncate(br.Offset()); err != nil {
+		return err
+	}
+	_, err = s.file.Seek(br.Offset(), io.SeekStart)
+
+	return err
+}
+
+func (s *BinarySink) Write(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("golog: binary sink is closed")
+	}
+
+	s.buf = s.enc.encode(s.buf[:0], entry)
+	_, err := s.file.Write(s.buf)
+
+	return err
+}
+
+func (s *BinarySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
@@ -0,0 +1,95 @@
+package golog_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestBinarySink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.glb")
+	now := time.Date(2018, 7, 1, 13, 5, 0, 0, time.UTC)
+
+	write := func(messages ...string) {
+		sink, err := golog.NewBinarySink(&golog.BinarySinkOption{Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, message := range messages {
+			sink.Write(&golog.Entry{
+				Level:   golog.LWarning,
+				Time:    now.Add(time.Duration(i) * time.Millisecond),
+				Caller:  "a.go:1",
+				Message: message,
+				Fields:  []golog.Field{{Key: "user", Value: "u1"}},
+			})
+		}
+		sink.Close()
+	}
+
+	write("first", "second")
+
+	// Simulate a torn record left by a crash, then append more entries.
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	file.Write([]byte{'E', 40, 1, 2})
+	file.Close()
+	write("third")
+
+	file, _ = os.Open(path)
+	defer file.Close()
+
+	reader, err := golog.NewBinaryReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []string
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.Level != golog.LWarning || entry.Caller != "a.go:1" || entry.Fields[0].Value != "u1" {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+		messages = append(messages, entry.Message)
+	}
+
+	if len(messages) != 3 || messages[0] != "first" || messages[2] != "third" {
+		t.Errorf("unexpected messages %q", messages)
+	}
+}
+
+func TestBinarySinkV1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.glb")
+	os.WriteFile(path, []byte("GLBIN001"), 0644)
+
+	sink, err := golog.NewBinarySink(&golog.BinarySinkOption{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Write(&golog.Entry{Level: golog.LWarning, Time: time.Now(), Caller: "a.go:1", Message: "old format"})
+	sink.Close()
+
+	data, _ := os.ReadFile(path)
+	file, _ := os.Open(path)
+	defer file.Close()
+	reader, err := golog.NewBinaryReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := reader.Next()
+	if err != nil || entry.Level != golog.LWarning || entry.Message != "old format" {
+		t.Errorf("unexpected entry %+v, %v", entry, err)
+	}
+	if string(data[:8]) != "GLBIN001" {
+		t.Errorf("magic rewritten: %q", data[:8])
+	}
+}
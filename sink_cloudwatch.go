@@ -0,0 +1,316 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// PutLogEvents limits, see the CloudWatch Logs API reference.
+const (
+	cloudWatchMaxEvents     = 10000
+	cloudWatchMaxBatchBytes = 1048576
+	cloudWatchEventOverhead = 26
+	cloudWatchMaxEventBytes = 256*1024 - cloudWatchEventOverhead
+	cloudWatchMaxSpan       = 24 * time.Hour
+)
+
+type CloudWatchSinkOption struct {
+	Region      string
+	Credentials AWSCredentials
+
+	LogGroup string
+
+	// LogStream defaults to hostname-pid.
+	LogStream string
+
+	// Format of the event messages; text messages use the default header.
+	Format Format
+
+	// Endpoint overrides https://logs.REGION.amazonaws.com.
+	Endpoint string
+
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+	HTTPClient    *http.Client
+}
+
+// CloudWatchSink sends entries to CloudWatch Logs with PutLogEvents. The
+// log group and stream are created on first use if they do not exist.
+// Batches are split to stay within the request's size, count and time span
+// limits, and the sequence token is tracked for accounts that still
+// enforce it.
+type CloudWatchSink struct {
+	url         string
+	region      string
+	credentials AWSCredentials
+	group       string
+	stream      string
+	format      Format
+	header      *template.Template
+	maxRetries  int
+	client      *http.Client
+	batch       *batcher
+
+	mu       sync.Mutex
+	token    string
+	prepared bool
+}
+
+type cloudWatchEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+type cloudWatchError struct {
+	Type                  string `json:"__type"`
+	Message               string `json:"message"`
+	ExpectedSequenceToken string `json:"expectedSequenceToken"`
+}
+
+func (e *cloudWatchError) Error() string {
+	return fmt.Sprintf("golog: cloudwatch %s: %s", e.Type, e.Message)
+}
+
+// is reports whether the error is of the given exception type, which may
+// be prefixed with a namespace.
+func (e *cloudWatchError) is(exception string) bool {
+	return e.Type == exception || strings.HasSuffix(e.Type, "#"+exception)
+}
+
+func NewCloudWatchSink(option *CloudWatchSinkOption) (*CloudWatchSink, error) {
+	if option == nil || option.LogGroup == "" {
+		return nil, errors.New("golog: cloudwatch sink requires a log group")
+	}
+
+	region := awsRegion(option.Region)
+	s := &CloudWatchSink{
+		url:         option.Endpoint,
+		region:      region,
+		credentials: option.Credentials.resolve(),
+		group:       option.LogGroup,
+		stream:      option.LogStream,
+		format:      option.Format,
+		header:      newDefaultHeader(),
+		maxRetries:  option.MaxRetries,
+		client:      option.HTTPClient,
+	}
+	if s.url == "" {
+		s.url = "https://logs." + region + ".amazonaws.com/"
+	}
+	if s.stream == "" {
+		hostname, _ := os.Hostname()
+		s.stream = hostname + "-" + strconv.Itoa(os.Getpid())
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 || maxBatch > cloudWatchMaxEvents {
+		maxBatch = cloudWatchMaxEvents
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	s.batch = newBatcher("cloudwatch sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *CloudWatchSink) Write(entry *Entry) error {
+	return s.batch.add(entry)
+}
+
+func (s *CloudWatchSink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *CloudWatchSink) Close() error {
+	return s.batch.close()
+}
+
+func (s *CloudWatchSink) message(entry *Entry) string {
+	message, ok := s.format.encode(entry)
+	if !ok {
+		message = renderHeader(s.header, entry, lineStyle{}) + entry.textMessage() + entry.stackSuffix()
+	}
+
+	if len(message) > cloudWatchMaxEventBytes {
+		// Cut at a rune boundary so the event stays valid UTF-8.
+		n := cloudWatchMaxEventBytes
+		for n > 0 && !utf8.RuneStart(message[n]) {
+			n--
+		}
+		message = message[:n]
+	}
+
+	return message
+}
+
+// send puts entries in as few requests as the limits allow, oldest first
+// as the API requires.
+func (s *CloudWatchSink) send(entries []Entry) error {
+	events := make([]cloudWatchEvent, len(entries))
+	for i := range entries {
+		events[i] = cloudWatchEvent{
+			Timestamp: entries[i].Time.UnixNano() / int64(time.Millisecond),
+			Message:   s.message(&entries[i]),
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	start, size := 0, 0
+	for i, event := range events {
+		eventSize := len(event.Message) + cloudWatchEventOverhead
+		if i > start && (size+eventSize > cloudWatchMaxBatchBytes ||
+			event.Timestamp-events[start].Timestamp >= int64(cloudWatchMaxSpan/time.Millisecond)) {
+			if err := s.put(events[start:i]); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			start, size = i, 0
+		}
+		size += eventSize
+	}
+	if err := s.put(events[start:]); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+func (s *CloudWatchSink) put(events []cloudWatchEvent) error {
+	if !s.prepared {
+		if err := s.prepare(); err != nil {
+			return err
+		}
+	}
+
+	created := false
+	for {
+		request := map[string]interface{}{
+			"logGroupName":  s.group,
+			"logStreamName": s.stream,
+			"logEvents":     events,
+		}
+		if s.token != "" {
+			request["sequenceToken"] = s.token
+		}
+
+		var response struct {
+			NextSequenceToken string `json:"nextSequenceToken"`
+		}
+		err := s.call("PutLogEvents", request, &response)
+
+		var cwErr *cloudWatchError
+		switch {
+		case err == nil:
+			s.token = response.NextSequenceToken
+			return nil
+		case !errors.As(err, &cwErr):
+			return err
+		case cwErr.is("InvalidSequenceTokenException") && cwErr.ExpectedSequenceToken != "" && cwErr.ExpectedSequenceToken != s.token:
+			s.token = cwErr.ExpectedSequenceToken
+		case cwErr.is("DataAlreadyAcceptedException"):
+			s.token = cwErr.ExpectedSequenceToken
+			return nil
+		case cwErr.is("ResourceNotFoundException") && !created:
+			// Deleted while we were writing; recreate once.
+			if err := s.prepare(); err != nil {
+				return err
+			}
+			created = true
+		default:
+			return err
+		}
+	}
+}
+
+// prepare creates the log group and stream unless they exist.
+func (s *CloudWatchSink) prepare() error {
+	for _, step := range []struct {
+		target  string
+		request map[string]interface{}
+	}{
+		{"CreateLogGroup", map[string]interface{}{"logGroupName": s.group}},
+		{"CreateLogStream", map[string]interface{}{"logGroupName": s.group, "logStreamName": s.stream}},
+	} {
+		err := s.call(step.target, step.request, nil)
+
+		var cwErr *cloudWatchError
+		if err != nil && !(errors.As(err, &cwErr) && cwErr.is("ResourceAlreadyExistsException")) {
+			return err
+		}
+	}
+
+	s.prepared = true
+	s.token = ""
+
+	return nil
+}
+
+// call invokes a CloudWatch Logs action, retrying transport errors,
+// throttling and server errors.
+func (s *CloudWatchSink) call(target string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+		req.Header.Set("X-Amz-Target", "Logs_20140328."+target)
+		signAWSv4(req, body, s.credentials, s.region, "logs", time.Now())
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return true, err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return true, err
+		}
+
+		if resp.StatusCode/100 == 2 {
+			if response != nil && len(data) > 0 {
+				return false, json.Unmarshal(data, response)
+			}
+			return false, nil
+		}
+
+		cwErr := &cloudWatchError{}
+		if json.Unmarshal(data, cwErr) != nil || cwErr.Type == "" {
+			cwErr.Type = resp.Status
+			cwErr.Message = string(data)
+		}
+		retry := resp.StatusCode >= 500 || cwErr.is("ThrottlingException")
+
+		return retry, cwErr
+	})
+}
@@ -0,0 +1,159 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestCloudWatchSink(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		targets []string
+		tokens  []string
+		batches [][]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		target := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "Logs_20140328.")
+		targets = append(targets, target)
+		if !strings.Contains(r.Header.Get("Authorization"), "/logs/aws4_request") {
+			t.Errorf("request not signed for logs: %q", r.Header.Get("Authorization"))
+		}
+
+		var request struct {
+			SequenceToken string `json:"sequenceToken"`
+			LogEvents     []struct {
+				Message string `json:"message"`
+			} `json:"logEvents"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+
+		switch target {
+		case "CreateLogGroup":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"__type":"ResourceAlreadyExistsException","message":"exists"}`)
+		case "PutLogEvents":
+			tokens = append(tokens, request.SequenceToken)
+			if len(tokens) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"__type":"InvalidSequenceTokenException","expectedSequenceToken":"t1"}`)
+				return
+			}
+			var messages []string
+			for _, event := range request.LogEvents {
+				messages = append(messages, event.Message)
+			}
+			batches = append(batches, messages)
+			io.WriteString(w, `{"nextSequenceToken":"t2"}`)
+		}
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewCloudWatchSink(&golog.CloudWatchSinkOption{
+		Credentials: golog.AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		LogGroup:    "app",
+		LogStream:   "web-1",
+		Format:      golog.FormatJSON,
+		Endpoint:    srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Message: "second"})
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now.Add(-time.Second), Message: "first"})
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now.Add(-48 * time.Hour), Message: "old"})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if strings.Join(targets[:2], ",") != "CreateLogGroup,CreateLogStream" {
+		t.Errorf("group and stream not created first: %v", targets)
+	}
+	if strings.Join(tokens, ",") != ",t1,t2" {
+		t.Errorf("unexpected sequence tokens %q", tokens)
+	}
+	if len(batches) != 2 || len(batches[0]) != 1 || len(batches[1]) != 2 {
+		t.Fatalf("expected batches split at 24h, got %q", batches)
+	}
+	if !strings.Contains(batches[0][0], `"old"`) || !strings.Contains(batches[1][0], `"first"`) {
+		t.Errorf("events not in time order: %q", batches)
+	}
+}
+
+func TestCloudWatchSinkTruncate(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			LogEvents []struct {
+				Message string `json:"message"`
+			} `json:"logEvents"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+
+		mu.Lock()
+		for _, event := range request.LogEvents {
+			messages = append(messages, event.Message)
+		}
+		mu.Unlock()
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewCloudWatchSink(&golog.CloudWatchSinkOption{
+		Credentials: golog.AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		LogGroup:    "app",
+		LogStream:   "web-1",
+		Endpoint:    srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Shift the multibyte runes so that every alignment meets the limit.
+	long := strings.Repeat("\u3042", 100000)
+	for _, shift := range []string{"", "a", "ab"} {
+		sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Message: shift + long})
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(messages))
+	}
+	for _, message := range messages {
+		if len(message) > 256*1024-26 || strings.ContainsRune(message, utf8.RuneError) {
+			t.Errorf("event of %d bytes not cut at a rune boundary", len(message))
+		}
+	}
+}
+
+func TestCloudWatchSinkRequiresGroup(t *testing.T) {
+	if _, err := golog.NewCloudWatchSink(&golog.CloudWatchSinkOption{}); err == nil {
+		t.Error("expected an error without a log group")
+	}
+}
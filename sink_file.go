@@ -0,0 +1,677 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+const defaultRotateNameTemplate = "{{.Base}}-{{.Date}}-{{.Seq}}{{.Ext}}"
+
+type FileSinkOption struct {
+	Path string
+
+	// MaxSize rotates the file once it would grow beyond this many bytes.
+	// 0 disables rotation.
+	MaxSize int64
+
+	// NameTemplate renders the name of a rotated file from RotateNameParam.
+	// It is resolved relative to the directory of Path.
+	NameTemplate string
+
+	// Shared makes the sink safe to use from several processes writing the
+	// same Path: every write and rotation holds an flock on Path+".lock",
+	// and a file rotated away by another process is reopened.
+	Shared bool
+
+	Fsync FsyncPolicy
+
+	// Colorize writes ANSI colors, e.g. for viewing with less -R, styled as
+	// ColorMode says.
+	Colorize  bool
+	ColorMode ColorMode
+
+	// Format of the lines written; text lines use the default header.
+	Format Format
+
+	// Clock dates files rotated by Rotate; it defaults to time.Now.
+	Clock func() time.Time
+
+	// Interval also rotates the file when an entry falls into a new hour or
+	// day. The rotated file is dated with the period it covers, so with
+	// RotateDaily there is one file per day.
+	Interval RotateInterval
+
+	// MaxBackups deletes the oldest rotated files once there are more than
+	// this many. 0 keeps them all.
+	MaxBackups int
+
+	// Compress gzips rotated files in the background, replacing each with a
+	// .gz file once it is complete. Close waits for pending compressions.
+	Compress bool
+}
+
+type RotateInterval uint8
+
+const (
+	RotateNever RotateInterval = iota
+	RotateHourly
+	RotateDaily
+)
+
+// start is the beginning of the period containing t.
+func (interval RotateInterval) start(t time.Time) time.Time {
+	switch interval {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	return time.Time{}
+}
+
+// FsyncPolicy decides when the file sink calls fsync. The zero value never
+// does and leaves write-back to the OS; the conditions can be combined.
+type FsyncPolicy struct {
+	// Every syncs after this many entries.
+	Every int
+
+	// Interval syncs pending writes at most this long after they were made.
+	Interval time.Duration
+
+	// MinLevel syncs right after any entry at or above this level.
+	MinLevel Level
+}
+
+// RotateNameParam is passed to FileSinkOption.NameTemplate. For Path
+// "/var/log/app.log", Base is "app" and Ext is ".log".
+type RotateNameParam struct {
+	Base     string
+	Ext      string
+	Date     string
+	Time     string
+	Seq      string
+	Hostname string
+	PID      string
+}
+
+// FileSink writes entries as plain text lines (the default header without
+// colors) to a file, optionally rotating it by size.
+type FileSink struct {
+	path         string
+	maxSize      int64
+	nameTemplate *texttemplate.Template
+	header       *texttemplate.Template
+	hostname     string
+	fsync        FsyncPolicy
+	clock        func() time.Time
+	style        lineStyle
+	interval     RotateInterval
+	maxBackups   int
+	compress     bool
+	format       Format
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	period   time.Time
+	lock     *os.File
+	unsynced int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	// compressQueue holds rotated files waiting for the single compression
+	// worker, oldest first; compressing is set while the worker runs.
+	compressMu    sync.Mutex
+	compressQueue []string
+	compressing   bool
+	compressions  sync.WaitGroup
+}
+
+func NewFileSink(option *FileSinkOption) (*FileSink, error) {
+	if option == nil || option.Path == "" {
+		return nil, errors.New("golog: file sink requires a path")
+	}
+
+	nameTemplate := option.NameTemplate
+	if nameTemplate == "" {
+		nameTemplate = defaultRotateNameTemplate
+	}
+	tmpl, err := texttemplate.New("GoLogRotateNameTemplate").Parse(nameTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileSink{
+		path:         option.Path,
+		maxSize:      option.MaxSize,
+		nameTemplate: tmpl,
+		header:       newDefaultHeader(),
+		fsync:        option.Fsync,
+		clock:        option.Clock,
+		style:        lineStyle{colorize: option.Colorize, mode: option.ColorMode, force: true},
+		interval:     option.Interval,
+		maxBackups:   option.MaxBackups,
+		compress:     option.Compress,
+		format:       option.Format,
+	}
+	if s.clock == nil {
+		s.clock = time.Now
+	}
+	s.hostname, _ = os.Hostname()
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	if option.Shared {
+		if s.lock, err = os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0644); err != nil {
+			s.file.Close()
+			return nil, err
+		}
+	}
+
+	if s.fsync.Interval > 0 {
+		s.done = make(chan struct{})
+		s.wg.Add(1)
+		go s.fsyncLoop()
+	}
+
+	return s, nil
+}
+
+func (s *FileSink) fsyncLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.fsync.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.file != nil && s.unsynced > 0 {
+				if err := s.syncFile(); err != nil {
+					reportSinkError(err)
+				}
+			}
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *FileSink) syncFile() error {
+	s.unsynced = 0
+	return s.file.Sync()
+}
+
+func (s *FileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	// An empty file takes the period of the first entry written to it.
+	s.period = time.Time{}
+	if s.size > 0 {
+		s.period = s.interval.start(info.ModTime())
+	}
+
+	return nil
+}
+
+func (s *FileSink) line(entry *Entry) string {
+	if text, ok := s.format.encode(entry); ok {
+		return text + "\n"
+	}
+
+	return formatLine(renderHeader(s.header, entry, s.style), entry.textMessage(), entry.Level, s.style) + entry.stackSuffix() + "\n"
+}
+
+func (s *FileSink) Write(entry *Entry) error {
+	line := s.line(entry)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("golog: file sink is closed")
+	}
+
+	if s.lock != nil {
+		if err := lockFile(s.lock); err != nil {
+			return err
+		}
+		defer unlockFile(s.lock)
+
+		if err := s.syncShared(); err != nil {
+			return err
+		}
+	}
+
+	if period := s.interval.start(entry.Time); period.After(s.period) {
+		if s.size > 0 {
+			if err := s.rotate(s.period); err != nil {
+				return err
+			}
+		}
+		s.period = period
+	}
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(entry.Time); err != nil {
+			return err
+		}
+	}
+
+	// A single write per line keeps O_APPEND writers from interleaving.
+	n, err := s.file.WriteString(line)
+	s.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	s.unsynced++
+	if (s.fsync.Every > 0 && s.unsynced >= s.fsync.Every) ||
+		(s.fsync.MinLevel != unknownLevel && entry.Level >= s.fsync.MinLevel) {
+		return s.syncFile()
+	}
+
+	return nil
+}
+
+// syncShared reopens Path if another process rotated it away and refreshes
+// the size, which other processes keep growing.
+func (s *FileSink) syncShared() error {
+	current, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	onDisk, err := os.Stat(s.path)
+	if err != nil || !os.SameFile(current, onDisk) {
+		s.file.Close()
+		s.file = nil
+		return s.open()
+	}
+
+	s.size = current.Size()
+
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+
+	if s.file == nil {
+		s.mu.Unlock()
+		return nil
+	}
+
+	err := s.closeFile()
+
+	if s.lock != nil {
+		s.lock.Close()
+		s.lock = nil
+	}
+
+	s.mu.Unlock()
+
+	if s.done != nil {
+		close(s.done)
+		s.wg.Wait()
+	}
+	s.compressions.Wait()
+
+	return err
+}
+
+// closeFile closes the active file, syncing it first if the policy asks for
+// syncs at all.
+func (s *FileSink) closeFile() error {
+	var err error
+	if s.unsynced > 0 && s.fsync != (FsyncPolicy{}) {
+		err = s.syncFile()
+	}
+
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	s.file = nil
+
+	return err
+}
+
+// Rotate moves the current file aside under the rotated name and reopens Path.
+func (s *FileSink) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lock != nil {
+		if err := lockFile(s.lock); err != nil {
+			return err
+		}
+		defer unlockFile(s.lock)
+	}
+
+	return s.rotate(s.clock())
+}
+
+func (s *FileSink) rotate(now time.Time) error {
+	name, err := s.rotatedName(now)
+	if err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		if err := s.closeFile(); err != nil {
+			return s.reopen(err)
+		}
+	}
+
+	if err := os.Rename(s.path, name); err != nil && !os.IsNotExist(err) {
+		return s.reopen(err)
+	}
+
+	if s.compress {
+		s.queueCompression(name)
+	} else {
+		s.pruneBackups()
+	}
+
+	return s.open()
+}
+
+// queueCompression hands name to the compression worker, starting it if it
+// is idle.
+func (s *FileSink) queueCompression(name string) {
+	s.compressMu.Lock()
+	defer s.compressMu.Unlock()
+
+	s.compressQueue = append(s.compressQueue, name)
+	if !s.compressing {
+		s.compressing = true
+		s.compressions.Add(1)
+		go s.compressLoop()
+	}
+}
+
+// compressLoop compresses queued files in the order they were rotated, so
+// backups are pruned only once every older file has its final name, and
+// exits when the queue is empty.
+func (s *FileSink) compressLoop() {
+	defer s.compressions.Done()
+
+	for {
+		s.compressMu.Lock()
+		if len(s.compressQueue) == 0 {
+			s.compressing = false
+			s.compressMu.Unlock()
+			return
+		}
+		name := s.compressQueue[0]
+		s.compressQueue = s.compressQueue[1:]
+		s.compressMu.Unlock()
+
+		if _, err := os.Stat(name); err == nil {
+			if err := gzipFile(name); err != nil {
+				reportSinkError(err)
+			}
+		}
+		s.pruneBackups()
+	}
+}
+
+// reopen opens Path again after a rotation failed with err, so later
+// writes still go to the active file, and returns err.
+func (s *FileSink) reopen(err error) error {
+	if oerr := s.open(); oerr != nil {
+		reportSinkError(oerr)
+	}
+
+	return err
+}
+
+// pruneBackups removes the oldest rotated files beyond maxBackups. Rotated
+// files are recognized by rendering the name template with wildcards for
+// the parts that vary, and ordered by the date, time and sequence number in
+// their names: modification times change when files are compressed.
+func (s *FileSink) pruneBackups() {
+	if s.maxBackups > 0 {
+		if err := s.removeOldBackups(); err != nil {
+			reportSinkError(err)
+		}
+	}
+}
+
+func (s *FileSink) removeOldBackups() error {
+	pattern, err := s.backupPattern()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var backups []backup
+	for _, file := range files {
+		name := filepath.Join(dir, file.Name())
+		b, ok := parseBackup(pattern, name)
+		if !file.Type().IsRegular() || !ok || name == filepath.Clean(s.path) {
+			continue
+		}
+		backups = append(backups, b)
+	}
+	if len(backups) <= s.maxBackups {
+		return nil
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		bi, bj := backups[i], backups[j]
+		if bi.date != bj.date {
+			return bi.date < bj.date
+		}
+		if bi.time != bj.time {
+			return bi.time < bj.time
+		}
+		if bi.seq != bj.seq {
+			return bi.seq < bj.seq
+		}
+		return bi.name < bj.name
+	})
+	for _, b := range backups[:len(backups)-s.maxBackups] {
+		if err := os.Remove(b.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backupPattern matches the names of rotated files, capturing the first
+// date, time and sequence number in them. Those have fixed formats, see
+// rotateParam, so they are told apart from the separators around them.
+func (s *FileSink) backupPattern() (*regexp.Regexp, error) {
+	const date, clock, seq, other = "\x00", "\x01", "\x02", "\x03"
+
+	param := s.rotateParam(time.Time{})
+	param.Date, param.Time, param.Seq, param.Hostname, param.PID = date, clock, seq, other, other
+
+	var buf bytes.Buffer
+	if err := s.nameTemplate.Execute(&buf, param); err != nil {
+		return nil, err
+	}
+
+	expr := regexp.QuoteMeta(buf.String())
+	for _, part := range []struct{ wildcard, group, expr string }{
+		{date, "date", `\d{4}-\d{2}-\d{2}`},
+		{clock, "time", `\d{6}`},
+		{seq, "seq", `\d+`},
+	} {
+		expr = strings.Replace(expr, part.wildcard, "(?P<"+part.group+">"+part.expr+")", 1)
+		expr = strings.ReplaceAll(expr, part.wildcard, part.expr)
+	}
+	expr = strings.ReplaceAll(expr, other, ".*")
+
+	return regexp.Compile("^" + expr + `(\.gz)?$`)
+}
+
+// lastSeq returns the highest sequence number of the backups dated as
+// param, 0 if there are none.
+func (s *FileSink) lastSeq(param RotateNameParam) int {
+	pattern, err := s.backupPattern()
+	if err != nil {
+		return 0
+	}
+	files, err := os.ReadDir(filepath.Dir(s.path))
+	if err != nil {
+		return 0
+	}
+
+	last := 0
+	for _, file := range files {
+		// Parts the template leaves out are captured empty.
+		b, ok := parseBackup(pattern, file.Name())
+		if ok && (b.date == "" || b.date == param.Date) && (b.time == "" || b.time == param.Time) && b.seq > last {
+			last = b.seq
+		}
+	}
+
+	return last
+}
+
+// backup is a rotated file with the parts of its name that order it.
+type backup struct {
+	name       string
+	date, time string
+	seq        int
+}
+
+// parseBackup reports whether the base of name matches pattern, see
+// backupPattern, and returns the parts captured from it.
+func parseBackup(pattern *regexp.Regexp, name string) (backup, bool) {
+	m := pattern.FindStringSubmatch(filepath.Base(name))
+	if m == nil {
+		return backup{}, false
+	}
+
+	b := backup{name: name}
+	for i, group := range pattern.SubexpNames() {
+		switch group {
+		case "date":
+			b.date = m[i]
+		case "time":
+			b.time = m[i]
+		case "seq":
+			b.seq, _ = strconv.Atoi(m[i])
+		}
+	}
+
+	return b, true
+}
+
+func (s *FileSink) rotateParam(now time.Time) RotateNameParam {
+	ext := filepath.Ext(s.path)
+
+	return RotateNameParam{
+		Base:     strings.TrimSuffix(filepath.Base(s.path), ext),
+		Ext:      ext,
+		Date:     now.Format("2006-01-02"),
+		Time:     now.Format("150405"),
+		Hostname: s.hostname,
+		PID:      strconv.Itoa(os.Getpid()),
+	}
+}
+
+// rotatedName renders the template with the first sequence number above
+// those of the backups of the same date and time, so sequence numbers of
+// pruned backups are not reused and keep following the rotation order.
+func (s *FileSink) rotatedName(now time.Time) (string, error) {
+	param := s.rotateParam(now)
+	dir := filepath.Dir(s.path)
+
+	for seq := s.lastSeq(param) + 1; ; seq++ {
+		param.Seq = strconv.Itoa(seq)
+
+		var buf bytes.Buffer
+		if err := s.nameTemplate.Execute(&buf, param); err != nil {
+			return "", err
+		}
+
+		name := filepath.Join(dir, buf.String())
+		if name == filepath.Clean(s.path) {
+			return "", fmt.Errorf("golog: rotated name %q equals the active file", name)
+		}
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			if _, err := os.Stat(name + ".gz"); os.IsNotExist(err) {
+				return name, nil
+			}
+		}
+	}
+}
+
+// gzipFile compresses name to name+".gz" and removes name. The archive is
+// written under a temporary name so a partial one is never mistaken for a
+// backup.
+func gzipFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := name + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, name+".gz")
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	src.Close()
+	return os.Remove(name)
+}
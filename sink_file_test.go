@@ -0,0 +1,286 @@
+package golog_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestFileSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := golog.NewFileSink(&golog.FileSinkOption{
+		Path:         path,
+		MaxSize:      100,
+		NameTemplate: "{{.Base}}.{{.Date}}.{{.Seq}}{{.Ext}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2018, 7, 1, 13, 5, 0, 0, time.Local)
+	for i := 0; i < 3; i++ {
+		sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Caller: "a.go:1", Message: strings.Repeat("x", 40)})
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"app.log", "app.2018-07-01.1.log", "app.2018-07-01.2.log"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), "[  info] 2018-07-01 13:05:00 (a.go:1): xxx") {
+			t.Errorf("%s: unexpected content %q", name, data)
+		}
+	}
+}
+
+func TestFileSinkShared(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	option := &golog.FileSinkOption{Path: path, MaxSize: 1000, Shared: true}
+
+	first, err := golog.NewFileSink(option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := golog.NewFileSink(option)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: strings.Repeat("x", 40)}
+	for i := 0; i < 30; i++ {
+		first.Write(entry)
+		second.Write(entry)
+	}
+	first.Close()
+	second.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "app*.log"))
+	var lines int
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		if len(data) > 1000 {
+			t.Errorf("%s: %d bytes exceeds MaxSize", file, len(data))
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if !strings.HasSuffix(line, strings.Repeat("x", 40)) {
+				t.Errorf("%s: corrupted line %q", file, line)
+			}
+			lines++
+		}
+	}
+	if lines != 60 {
+		t.Errorf("expected 60 lines across %d files, got %d", len(files), lines)
+	}
+}
+
+func TestFileSinkFsync(t *testing.T) {
+	for _, policy := range []golog.FsyncPolicy{
+		{},
+		{Every: 2},
+		{Interval: time.Millisecond},
+		{MinLevel: golog.LError},
+	} {
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		sink, err := golog.NewFileSink(&golog.FileSinkOption{Path: path, Fsync: policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, level := range []golog.Level{golog.LInfo, golog.LError, golog.LInfo} {
+			if err := sink.Write(&golog.Entry{Level: level, Time: time.Now(), Message: "test"}); err != nil {
+				t.Errorf("%+v: %v", policy, err)
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if err := sink.Close(); err != nil {
+			t.Errorf("%+v: %v", policy, err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if n := strings.Count(string(data), "\n"); n != 3 {
+			t.Errorf("%+v: expected 3 lines, got %d", policy, n)
+		}
+	}
+}
+
+func TestFileSinkClock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := golog.NewFileSink(&golog.FileSinkOption{
+		Path:         path,
+		NameTemplate: "{{.Base}}.{{.Date}}{{.Ext}}",
+		Clock:        func() time.Time { return time.Date(2018, 7, 2, 0, 0, 0, 0, time.Local) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: "x"})
+	if err := sink.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "app.2018-07-02.log")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileSinkPruneOrder(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := golog.NewFileSink(&golog.FileSinkOption{
+		Path:       path,
+		MaxBackups: 2,
+		Clock:      func() time.Time { return time.Date(2018, 7, 2, 0, 0, 0, 0, time.Local) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 11; i++ {
+		sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: "x"})
+		if err := sink.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		// Backups touched later must not count as newer.
+		os.Chtimes(filepath.Join(dir, "app-2018-07-02-1.log"), time.Now(), time.Now().Add(time.Hour))
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+	want := []string{"app-2018-07-02-10.log", "app-2018-07-02-11.log"}
+	if len(files) != len(want) || filepath.Base(files[0]) != want[0] || filepath.Base(files[1]) != want[1] {
+		t.Errorf("expected %v, got %v", want, files)
+	}
+}
+
+func TestFileSinkColorMode(t *testing.T) {
+	entry := &golog.Entry{Level: golog.LError, Time: time.Date(2018, 7, 1, 13, 5, 0, 0, time.Local), Caller: "a.go:1", Message: "failed"}
+	tests := []struct {
+		mode golog.ColorMode
+		want string
+	}{
+		{golog.ColorLevel, "[\x1b[31m error\x1b[0m] 2018-07-01 13:05:00 (\x1b[36ma.go:1\x1b[0m): failed\n"},
+		{golog.ColorLine, "\x1b[31m[ error] 2018-07-01 13:05:00 (a.go:1): failed\x1b[0m\n"},
+		{golog.ColorMessage, "[ error] 2018-07-01 13:05:00 (a.go:1): \x1b[31mfailed\x1b[0m\n"},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "app.log")
+		sink, err := golog.NewFileSink(&golog.FileSinkOption{Path: path, Colorize: true, ColorMode: test.mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sink.Write(entry)
+		sink.Close()
+
+		data, _ := os.ReadFile(path)
+		if string(data) != test.want {
+			t.Errorf("mode %d: got %q, want %q", test.mode, data, test.want)
+		}
+	}
+}
+
+func TestFileSinkDailyRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := golog.NewFileSink(&golog.FileSinkOption{
+		Path:         path,
+		NameTemplate: "{{.Base}}.{{.Date}}{{.Ext}}",
+		Interval:     golog.RotateDaily,
+		MaxBackups:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for day := 1; day <= 4; day++ {
+		for _, hour := range []int{9, 23} {
+			sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Date(2018, 7, day, hour, 0, 0, 0, time.Local), Caller: "a.go:1", Message: "x"})
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "app*.log"))
+	want := []string{"app.2018-07-02.log", "app.2018-07-03.log", "app.log"}
+	if len(files) != len(want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+	for i, file := range files {
+		if filepath.Base(file) != want[i] {
+			t.Errorf("expected %s, got %s", want[i], file)
+		}
+		data, _ := os.ReadFile(file)
+		if n := strings.Count(string(data), "\n"); n != 2 {
+			t.Errorf("%s: expected 2 lines, got %d", file, n)
+		}
+	}
+}
+
+func TestFileSinkCompress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := golog.NewFileSink(&golog.FileSinkOption{
+		Path:       path,
+		Interval:   golog.RotateDaily,
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for day := 1; day <= 4; day++ {
+		sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Date(2018, 7, day, 9, 0, 0, 0, time.Local), Caller: "a.go:1", Message: "x"})
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	want := []string{"app-2018-07-02-1.log.gz", "app-2018-07-03-1.log.gz", "app.log"}
+	if len(files) != len(want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+	for i, file := range files {
+		if filepath.Base(file) != want[i] {
+			t.Errorf("expected %s, got %s", want[i], file)
+		}
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "[  info] 2018-07-02 09:00:00 (a.go:1): x") {
+		t.Errorf("unexpected content %q", data)
+	}
+}
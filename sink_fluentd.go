@@ -0,0 +1,245 @@
+package golog
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+type FluentdSinkOption struct {
+	// Network and Address of the forward input; "tcp" and
+	// "127.0.0.1:24224" when empty.
+	Network string
+	Address string
+
+	// Tag routes the events in Fluentd; "golog" when empty.
+	Tag string
+
+	// Ack asks Fluentd to acknowledge every batch, so a batch lost with a
+	// dropped connection is resent instead of silently missing.
+	Ack bool
+
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+	Timeout       time.Duration
+}
+
+// FluentdSink sends entries to Fluentd or Fluent Bit using the forward
+// protocol, batched in Forward mode with nanosecond event times. Every
+// field is a key of the record, so nothing is lost to text parsing. Failed
+// batches are retried over a fresh connection.
+type FluentdSink struct {
+	network    string
+	address    string
+	tag        string
+	ack        bool
+	maxRetries int
+	timeout    time.Duration
+	batch      *batcher
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func NewFluentdSink(option *FluentdSinkOption) (*FluentdSink, error) {
+	if option == nil {
+		option = &FluentdSinkOption{}
+	}
+
+	s := &FluentdSink{
+		network:    option.Network,
+		address:    option.Address,
+		tag:        option.Tag,
+		ack:        option.Ack,
+		maxRetries: option.MaxRetries,
+		timeout:    option.Timeout,
+	}
+	if s.network == "" {
+		s.network = "tcp"
+	}
+	if s.address == "" {
+		s.address = "127.0.0.1:24224"
+	}
+	if s.tag == "" {
+		s.tag = "golog"
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	if s.timeout <= 0 {
+		s.timeout = 5 * time.Second
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 500
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s.batch = newBatcher("fluentd sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *FluentdSink) Write(entry *Entry) error {
+	return s.batch.add(entry)
+}
+
+func (s *FluentdSink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *FluentdSink) Close() error {
+	err := s.batch.close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+// message encodes entries as [tag, [[time, record], ...], option].
+func (s *FluentdSink) message(entries []Entry, chunk string) []byte {
+	buf := appendMsgpackArrayHeader(nil, 3)
+	buf = appendMsgpackString(buf, s.tag)
+
+	buf = appendMsgpackArrayHeader(buf, len(entries))
+	for i := range entries {
+		record := entries[i].values()
+		delete(record, "time")
+
+		buf = appendMsgpackArrayHeader(buf, 2)
+		buf = appendMsgpackEventTime(buf, entries[i].Time)
+		buf = appendMsgpackMap(buf, record)
+	}
+
+	option := map[string]interface{}{"size": len(entries)}
+	if chunk != "" {
+		option["chunk"] = chunk
+	}
+
+	return appendMsgpackMap(buf, option)
+}
+
+func (s *FluentdSink) send(entries []Entry) error {
+	var chunk string
+	if s.ack {
+		id := make([]byte, 16)
+		rand.Read(id)
+		chunk = base64.StdEncoding.EncodeToString(id)
+	}
+	msg := s.message(entries, chunk)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		err := s.transmit(msg, chunk)
+		if err != nil && s.conn != nil {
+			s.conn.Close()
+			s.conn = nil
+		}
+
+		return true, err
+	})
+}
+
+func (s *FluentdSink) transmit(msg []byte, chunk string) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+		s.r = bufio.NewReader(conn)
+	}
+
+	s.conn.SetDeadline(time.Now().Add(s.timeout))
+	if _, err := s.conn.Write(msg); err != nil {
+		return err
+	}
+	if chunk == "" {
+		return nil
+	}
+
+	ack, err := readFluentdAck(s.r)
+	if err != nil {
+		return err
+	}
+	if ack != chunk {
+		return fmt.Errorf("golog: fluentd acknowledged chunk %q, expected %q", ack, chunk)
+	}
+
+	return nil
+}
+
+// readFluentdAck reads a {"ack": chunk} response.
+func readFluentdAck(r *bufio.Reader) (string, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	if header&0xf0 != 0x80 {
+		return "", errors.New("golog: unexpected fluentd response")
+	}
+
+	var ack string
+	for i := 0; i < int(header&0x0f); i++ {
+		key, err := readMsgpackString(r)
+		if err != nil {
+			return "", err
+		}
+		value, err := readMsgpackString(r)
+		if err != nil {
+			return "", err
+		}
+		if key == "ack" {
+			ack = value
+		}
+	}
+
+	return ack, nil
+}
+
+func readMsgpackString(r *bufio.Reader) (string, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	var n int
+	switch {
+	case header&0xe0 == 0xa0:
+		n = int(header & 0x1f)
+	case header == 0xd9:
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		n = int(b)
+	default:
+		return "", errors.New("golog: unexpected fluentd response")
+	}
+
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
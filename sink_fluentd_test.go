@@ -0,0 +1,95 @@
+package golog_test
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+// readForward reads one forward-mode message, which the sink ends with the
+// option map {"chunk": ..., "size": n}, and returns it with its chunk id.
+func readForward(conn net.Conn) ([]byte, string, error) {
+	var data []byte
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if i := bytes.Index(data, []byte("\xa4size")); i >= 0 && len(data) > i+5 {
+			break
+		}
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, "", err
+		}
+		data = append(data, buf[:n]...)
+	}
+
+	i := bytes.Index(data, []byte("\xa5chunk\xb8"))
+	if i < 0 {
+		return nil, "", fmt.Errorf("no chunk in %q", data)
+	}
+	i += len("\xa5chunk\xb8")
+
+	return data, string(data[i : i+24]), nil
+}
+
+func TestFluentdSink(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		// The first connection drops the batch without acknowledging it.
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		readForward(conn)
+		conn.Close()
+
+		conn, err = ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, chunk, err := readForward(conn)
+		if err != nil {
+			t.Error(err)
+		}
+		conn.Write(append([]byte("\x81\xa3ack\xb8"), chunk...))
+		received <- data
+	}()
+
+	sink, err := golog.NewFluentdSink(&golog.FluentdSinkOption{Address: ln.Addr().String(), Tag: "app.logs", Ack: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink.Write(&golog.Entry{
+		Level:   golog.LWarning,
+		Time:    time.Unix(1530403200, 5),
+		Caller:  "a.go:1",
+		Message: "disk full",
+		Fields:  []golog.Field{{Key: "used", Value: 0.93}},
+	})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := <-received
+	prefix := "\x93\xa8app.logs\x91\x92\xd7\x00\x5b\x38\x19\x80\x00\x00\x00\x05"
+	if !bytes.HasPrefix(data, []byte(prefix)) {
+		t.Errorf("unexpected message start %q", data)
+	}
+	for _, part := range []string{"\xa7message\xa9disk full", "\xa5level\xa4warn", "\xa4used\xcb"} {
+		if !bytes.Contains(data, []byte(part)) {
+			t.Errorf("message lacks %q: %q", part, data)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gelfChunkMagic  = "\x1e\x0f"
+	gelfChunkHeader = 12
+	gelfMaxChunks   = 128
+
+	// gelfMinChunkSize leaves room for the chunk header and some payload.
+	gelfMinChunkSize = 64
+)
+
+type GELFSinkOption struct {
+	// Network is "udp" or "tcp"; Address is the Graylog input.
+	Network string
+	Address string
+
+	// Host identifies the source; it defaults to the hostname.
+	Host string
+
+	// ChunkSize limits UDP datagrams; larger messages are chunked. 1420
+	// when 0, and at least 64 otherwise.
+	ChunkSize int
+
+	// Compress gzips UDP messages.
+	Compress bool
+
+	DialTimeout time.Duration
+}
+
+// GELFSink sends entries to Graylog as GELF 1.1 messages. The level maps
+// to a syslog severity, the stack trace becomes full_message and fields
+// become additional fields prefixed with an underscore. Over UDP, messages
+// larger than ChunkSize are chunked; over TCP they are null-terminated.
+type GELFSink struct {
+	network   string
+	address   string
+	host      string
+	chunkSize int
+	compress  bool
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func NewGELFSink(option *GELFSinkOption) (*GELFSink, error) {
+	if option == nil || option.Network == "" || option.Address == "" {
+		return nil, errors.New("golog: gelf sink requires a network and address")
+	}
+	if option.Network != "udp" && option.Network != "tcp" {
+		return nil, errors.New(`golog: gelf sink network must be "udp" or "tcp"`)
+	}
+	if option.ChunkSize > 0 && option.ChunkSize < gelfMinChunkSize {
+		return nil, fmt.Errorf("golog: gelf chunk size must be at least %d", gelfMinChunkSize)
+	}
+
+	s := &GELFSink{
+		network:   option.Network,
+		address:   option.Address,
+		host:      option.Host,
+		chunkSize: option.ChunkSize,
+		compress:  option.Compress && option.Network == "udp",
+		timeout:   option.DialTimeout,
+	}
+	if s.host == "" {
+		s.host, _ = os.Hostname()
+	}
+	if s.chunkSize <= 0 {
+		s.chunkSize = 1420
+	}
+	if s.timeout <= 0 {
+		s.timeout = 5 * time.Second
+	}
+
+	return s, nil
+}
+
+// gelfMessage encodes entry as a GELF 1.1 message.
+func gelfMessage(entry *Entry, host string) ([]byte, error) {
+	msg := map[string]interface{}{
+		"version":       "1.1",
+		"host":          host,
+		"short_message": entry.Message,
+		"timestamp":     float64(entry.Time.UnixNano()/int64(time.Millisecond)) / 1000,
+		"level":         syslogSeverity(entry.Level),
+		"_caller":       entry.Caller,
+		"_level_name":   trimLevel(entry.Level),
+	}
+	if entry.File != "" {
+		msg["_file"] = entry.File
+		msg["_line"] = entry.Line
+	}
+	if entry.ID != "" {
+		msg["_entry_id"] = entry.ID
+	}
+
+	for _, field := range entry.Fields {
+		value := field.Value
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+
+		if field.Key == "stacktrace" {
+			msg["full_message"] = entry.Message + "\n" + fmt.Sprint(value)
+			continue
+		}
+		msg[gelfFieldName(field.Key)] = value
+	}
+
+	return json.Marshal(msg)
+}
+
+// gelfFieldName prefixes key with an underscore and replaces characters
+// GELF does not allow in field names. _id is reserved.
+func gelfFieldName(key string) string {
+	name := "_" + strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "_id" {
+		name = "__id"
+	}
+
+	return name
+}
+
+func (s *GELFSink) Write(entry *Entry) error {
+	msg, err := gelfMessage(entry, s.host)
+	if err != nil {
+		return err
+	}
+
+	if s.compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(msg)
+		zw.Close()
+		msg = buf.Bytes()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if s.network == "tcp" {
+		err = s.writeAll(append(msg, 0))
+	} else {
+		err = s.writeChunked(msg)
+	}
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+func (s *GELFSink) writeAll(b []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	_, err := s.conn.Write(b)
+	return err
+}
+
+// writeChunked sends msg in one datagram, or in chunks of at most
+// chunkSize bytes sharing a random message id.
+func (s *GELFSink) writeChunked(msg []byte) error {
+	if len(msg) <= s.chunkSize {
+		return s.writeAll(msg)
+	}
+
+	size := s.chunkSize - gelfChunkHeader
+	count := (len(msg) + size - 1) / size
+	if count > gelfMaxChunks {
+		return errors.New("golog: gelf message is too large to chunk")
+	}
+
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	chunk := make([]byte, 0, s.chunkSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * size
+		if end > len(msg) {
+			end = len(msg)
+		}
+
+		chunk = append(chunk[:0], gelfChunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, msg[i*size:end]...)
+		if err := s.writeAll(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GELFSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
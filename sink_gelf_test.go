@@ -0,0 +1,135 @@
+package golog_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestGELFSinkChunked(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := golog.NewGELFSink(&golog.GELFSinkOption{Network: "udp", Address: conn.LocalAddr().String(), Host: "web-1", ChunkSize: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{
+		Level:   golog.LError,
+		Time:    time.Unix(1530403200, 250*int64(time.Millisecond)),
+		Caller:  "db.go:12",
+		Message: strings.Repeat("x", 100),
+		Fields:  []golog.Field{{Key: "user id", Value: 42}, {Key: "id", Value: "r-1"}, {Key: "stacktrace", Value: "main.query\n\t/src/db.go:12"}},
+	})
+
+	var parts [][]byte
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunk := buf[:n]
+		if len(chunk) > 64 || string(chunk[:2]) != "\x1e\x0f" {
+			t.Fatalf("unexpected chunk %q", chunk)
+		}
+		if parts == nil {
+			parts = make([][]byte, chunk[11])
+		}
+		parts[chunk[10]] = append([]byte(nil), chunk[12:]...)
+
+		complete := true
+		for _, part := range parts {
+			complete = complete && part != nil
+		}
+		if complete {
+			break
+		}
+	}
+
+	var msg map[string]interface{}
+	var data []byte
+	for _, part := range parts {
+		data = append(data, part...)
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"version":      "1.1",
+		"host":         "web-1",
+		"level":        3.0,
+		"timestamp":    1530403200.25,
+		"_caller":      "db.go:12",
+		"_user_id":     42.0,
+		"__id":         "r-1",
+		"full_message": strings.Repeat("x", 100) + "\nmain.query\n\t/src/db.go:12",
+	}
+	for key, value := range want {
+		if msg[key] != value {
+			t.Errorf("%s: got %v, want %v", key, msg[key], value)
+		}
+	}
+}
+
+func TestGELFSinkChunkSizeInvalid(t *testing.T) {
+	for _, size := range []int{1, 12, 63} {
+		if _, err := golog.NewGELFSink(&golog.GELFSinkOption{Network: "udp", Address: "127.0.0.1:12201", ChunkSize: size}); err == nil {
+			t.Errorf("chunk size %d: expected an error", size)
+		}
+	}
+}
+
+func TestGELFSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	sink, err := golog.NewGELFSink(&golog.GELFSinkOption{Network: "tcp", Address: ln.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for _, message := range []string{"one", "two"} {
+		if err := sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	r := bufio.NewReader(conn)
+	for _, message := range []string{"one", "two"} {
+		frame, err := r.ReadBytes(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg map[string]interface{}
+		if err := json.Unmarshal(frame[:len(frame)-1], &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg["short_message"] != message || msg["level"] != 6.0 {
+			t.Errorf("unexpected message %v", msg)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const honeycombEndpoint = "https://api.honeycomb.io"
+
+type HoneycombSinkOption struct {
+	APIKey  string
+	Dataset string
+
+	// SampleRate keeps one in SampleRate entries and reports the rate to
+	// Honeycomb so counts are scaled back up. 0 and 1 keep everything.
+	SampleRate uint
+
+	// Rand decides which entries are kept; seed it for reproducible tests.
+	Rand *Rand
+
+	Endpoint      string
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+	HTTPClient    *http.Client
+}
+
+// HoneycombSink sends entries as Honeycomb events, one attribute per field.
+// A "sample_rate" field set by upstream sampling is folded into the event's
+// sample rate.
+type HoneycombSink struct {
+	url        string
+	apiKey     string
+	sampleRate uint
+	rand       *Rand
+	maxRetries int
+	client     *http.Client
+	batch      *batcher
+}
+
+type honeycombEvent struct {
+	Time       string                 `json:"time"`
+	SampleRate uint                   `json:"samplerate,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+func NewHoneycombSink(option *HoneycombSinkOption) (*HoneycombSink, error) {
+	if option == nil || option.APIKey == "" || option.Dataset == "" {
+		return nil, errors.New("golog: honeycomb sink requires an api key and dataset")
+	}
+
+	endpoint := option.Endpoint
+	if endpoint == "" {
+		endpoint = honeycombEndpoint
+	}
+
+	s := &HoneycombSink{
+		url:        strings.TrimRight(endpoint, "/") + "/1/batch/" + url.PathEscape(option.Dataset),
+		apiKey:     option.APIKey,
+		sampleRate: option.SampleRate,
+		rand:       option.Rand,
+		maxRetries: option.MaxRetries,
+		client:     option.HTTPClient,
+	}
+	if s.sampleRate == 0 {
+		s.sampleRate = 1
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 500
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s.batch = newBatcher("honeycomb sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *HoneycombSink) Write(entry *Entry) error {
+	if s.sampleRate > 1 && s.rand.Intn(int(s.sampleRate)) != 0 {
+		return nil
+	}
+
+	return s.batch.add(entry)
+}
+
+func (s *HoneycombSink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *HoneycombSink) Close() error {
+	return s.batch.close()
+}
+
+func (s *HoneycombSink) event(entry *Entry) honeycombEvent {
+	data := entry.values()
+	delete(data, "time")
+
+	rate := s.sampleRate
+	if v, ok := data["sample_rate"]; ok {
+		if n, ok := toUint(v); ok && n > 0 {
+			rate *= n
+		}
+		delete(data, "sample_rate")
+	}
+	if rate == 1 {
+		rate = 0
+	}
+
+	return honeycombEvent{
+		Time:       entry.Time.Format(time.RFC3339Nano),
+		SampleRate: rate,
+		Data:       data,
+	}
+}
+
+func (s *HoneycombSink) send(entries []Entry) error {
+	events := make([]honeycombEvent, len(entries))
+	for i := range entries {
+		events[i] = s.event(&entries[i])
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Honeycomb-Team", s.apiKey)
+
+		return doRequest(s.client, req, "honeycomb batch")
+	})
+}
+
+func toUint(v interface{}) (uint, bool) {
+	switch n := v.(type) {
+	case int:
+		return uint(n), n >= 0
+	case int64:
+		return uint(n), n >= 0
+	case uint:
+		return n, true
+	case uint64:
+		return uint(n), true
+	case float64:
+		return uint(n), n >= 0
+	}
+
+	var n uint
+	_, err := fmt.Sscan(fmt.Sprint(v), &n)
+	return n, err == nil
+}
@@ -0,0 +1,101 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestHoneycombSink(t *testing.T) {
+	var events []struct {
+		SampleRate uint                   `json:"samplerate"`
+		Data       map[string]interface{} `json:"data"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/batch/api-logs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Honeycomb-Team") != "key" {
+			t.Errorf("unexpected team header %q", r.Header.Get("X-Honeycomb-Team"))
+		}
+		json.NewDecoder(r.Body).Decode(&events)
+		w.Write([]byte(`[{"status":202}]`))
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewHoneycombSink(&golog.HoneycombSinkOption{
+		APIKey:   "key",
+		Dataset:  "api-logs",
+		Endpoint: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink.Write(&golog.Entry{
+		Level:   golog.LDebug,
+		Time:    time.Now(),
+		Message: "test",
+		Fields:  []golog.Field{{Key: "user", Value: "u1"}, {Key: "sample_rate", Value: 10}},
+	})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].SampleRate != 10 || events[0].Data["user"] != "u1" || events[0].Data["message"] != "test" {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+}
+
+func TestHoneycombSinkSeededSampling(t *testing.T) {
+	kept := func() []string {
+		var messages []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var events []struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			json.NewDecoder(r.Body).Decode(&events)
+			for _, event := range events {
+				messages = append(messages, event.Data["message"].(string))
+			}
+			w.Write([]byte(`[]`))
+		}))
+		defer srv.Close()
+
+		sink, err := golog.NewHoneycombSink(&golog.HoneycombSinkOption{
+			APIKey:     "key",
+			Dataset:    "api-logs",
+			Endpoint:   srv.URL,
+			SampleRate: 4,
+			Rand:       golog.NewRand(1),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Message: fmt.Sprint(i)})
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return messages
+	}
+
+	first, second := kept(), kept()
+	if len(first) == 0 || len(first) == 100 {
+		t.Fatalf("expected sampling to drop some entries, kept %d", len(first))
+	}
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("equally seeded runs kept different entries:\n%v\n%v", first, second)
+	}
+}
@@ -0,0 +1,155 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const defaultJournalSocket = "/run/systemd/journal/socket"
+
+type JournaldSinkOption struct {
+	// Socket defaults to /run/systemd/journal/socket.
+	Socket string
+
+	// Identifier is SYSLOG_IDENTIFIER; it defaults to the program name.
+	Identifier string
+}
+
+// JournaldSink writes entries to systemd-journald over its native
+// protocol. The level becomes PRIORITY, so journalctl -p works, the call
+// site becomes CODE_FILE, CODE_LINE and CODE_FUNC, and every field is sent
+// as a journal field of its own, its key upper-cased with characters
+// journald does not accept replaced by underscores.
+type JournaldSink struct {
+	identifier string
+
+	mu   sync.Mutex
+	conn *net.UnixConn
+	addr *net.UnixAddr
+	buf  bytes.Buffer
+}
+
+func NewJournaldSink(option *JournaldSinkOption) (*JournaldSink, error) {
+	if option == nil {
+		option = &JournaldSinkOption{}
+	}
+
+	socket := option.Socket
+	if socket == "" {
+		socket = defaultJournalSocket
+	}
+
+	s := &JournaldSink{
+		identifier: option.Identifier,
+		addr:       &net.UnixAddr{Name: socket, Net: "unixgram"},
+	}
+	if s.identifier == "" {
+		s.identifier = filepath.Base(os.Args[0])
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+
+	return s, nil
+}
+
+func (s *JournaldSink) Write(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return errors.New("golog: journald sink is closed")
+	}
+
+	s.buf.Reset()
+	appendJournalField(&s.buf, "MESSAGE", entry.Message)
+	appendJournalField(&s.buf, "PRIORITY", strconv.Itoa(syslogSeverity(entry.Level)))
+	appendJournalField(&s.buf, "SYSLOG_IDENTIFIER", s.identifier)
+	if entry.File != "" {
+		appendJournalField(&s.buf, "CODE_FILE", entry.File)
+		appendJournalField(&s.buf, "CODE_LINE", strconv.Itoa(entry.Line))
+	}
+	if entry.Function != "" {
+		appendJournalField(&s.buf, "CODE_FUNC", entry.Function)
+	}
+	if entry.ID != "" {
+		appendJournalField(&s.buf, "GOLOG_ID", entry.ID)
+	}
+	for _, field := range entry.Fields {
+		appendJournalField(&s.buf, journalFieldName(field.Key), fmt.Sprint(field.Value))
+	}
+
+	_, _, err := s.conn.WriteMsgUnix(s.buf.Bytes(), nil, s.addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		// Too large for a datagram: journald accepts the payload as a file
+		// descriptor instead.
+		err = sendJournalFD(s.conn, s.addr, s.buf.Bytes())
+	}
+
+	return err
+}
+
+func (s *JournaldSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// appendJournalField encodes a field in the native protocol: KEY=value, or
+// for values containing newlines, KEY, a little-endian 64-bit length and
+// the raw value.
+func appendJournalField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName turns key into a valid journal field name: upper case
+// letters, digits and underscores, not starting with an underscore or a
+// digit.
+func journalFieldName(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+
+	name := strings.TrimLeft(b.String(), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "F_" + name
+	}
+
+	return name
+}
@@ -0,0 +1,90 @@
+//go:build linux
+
+package golog_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestJournaldSink(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := golog.NewJournaldSink(&golog.JournaldSinkOption{Socket: socket, Identifier: "app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	entry := &golog.Entry{
+		Level:    golog.LError,
+		Time:     time.Now(),
+		Message:  "query failed",
+		File:     "/src/db.go",
+		Line:     12,
+		Function: "main.query",
+		Fields: []golog.Field{
+			{Key: "user-id", Value: 42},
+			{Key: "stacktrace", Value: "db.go:12\nmain.go:5"},
+		},
+	}
+	if err := sink.Write(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]string{}
+	data := buf[:n]
+	for len(data) > 0 {
+		i := bytes.IndexAny(data, "=\n")
+		if i < 0 {
+			t.Fatalf("malformed payload %q", buf[:n])
+		}
+		key := string(data[:i])
+		if data[i] == '=' {
+			end := bytes.IndexByte(data, '\n')
+			fields[key] = string(data[i+1 : end])
+			data = data[end+1:]
+			continue
+		}
+		size := binary.LittleEndian.Uint64(data[i+1:])
+		fields[key] = string(data[i+9 : i+9+int(size)])
+		data = data[i+9+int(size)+1:]
+	}
+
+	want := map[string]string{
+		"MESSAGE":           "query failed",
+		"PRIORITY":          "3",
+		"SYSLOG_IDENTIFIER": "app",
+		"CODE_FILE":         "/src/db.go",
+		"CODE_LINE":         "12",
+		"CODE_FUNC":         "main.query",
+		"USER_ID":           "42",
+		"STACKTRACE":        "db.go:12\nmain.go:5",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s: got %q, want %q", key, fields[key], value)
+		}
+	}
+	if len(fields) != len(want) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
@@ -0,0 +1,220 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+type NetworkSinkOption struct {
+	// URL is tcp://host:port or udp://host:port.
+	URL string
+
+	// Format of the lines sent; text lines use the default header.
+	Format Format
+
+	DialTimeout time.Duration
+
+	// MaxBuffered is how many lines are kept while the collector is
+	// unreachable, 10000 when 0. Beyond that the oldest are dropped.
+	MaxBuffered int
+}
+
+// NetworkSink streams entries as newline-terminated lines to a collector
+// over TCP, or one datagram per entry over UDP. Writes only queue the line;
+// a background goroutine sends it, reconnecting with exponential backoff
+// while the collector is unreachable.
+type NetworkSink struct {
+	network     string
+	address     string
+	format      Format
+	header      *template.Template
+	timeout     time.Duration
+	maxBuffered int
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []string
+	dropped int
+	closed  bool
+
+	conn net.Conn
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewNetworkSink(option *NetworkSinkOption) (*NetworkSink, error) {
+	if option == nil || option.URL == "" {
+		return nil, errors.New("golog: network sink requires a url")
+	}
+
+	u, err := url.Parse(option.URL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, fmt.Errorf("golog: network sink does not support %q", option.URL)
+	}
+
+	s := &NetworkSink{
+		network:     u.Scheme,
+		address:     u.Host,
+		format:      option.Format,
+		header:      newDefaultHeader(),
+		timeout:     option.DialTimeout,
+		maxBuffered: option.MaxBuffered,
+		done:        make(chan struct{}),
+	}
+	if s.timeout <= 0 {
+		s.timeout = 5 * time.Second
+	}
+	if s.maxBuffered <= 0 {
+		s.maxBuffered = 10000
+	}
+	s.cond = sync.NewCond(&s.mu)
+
+	s.wg.Add(1)
+	go s.loop()
+
+	return s, nil
+}
+
+func (s *NetworkSink) line(entry *Entry) string {
+	if text, ok := s.format.encode(entry); ok {
+		return text + "\n"
+	}
+
+	return renderHeader(s.header, entry, lineStyle{}) + entry.textMessage() + entry.stackSuffix() + "\n"
+}
+
+func (s *NetworkSink) Write(entry *Entry) error {
+	line := s.line(entry)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("golog: network sink is closed")
+	}
+
+	s.enqueue(line)
+	s.cond.Signal()
+
+	return nil
+}
+
+// enqueue appends lines, dropping the oldest beyond maxBuffered; s.mu must
+// be held.
+func (s *NetworkSink) enqueue(lines ...string) {
+	s.queue = append(s.queue, lines...)
+	if over := len(s.queue) - s.maxBuffered; over > 0 {
+		s.queue = s.queue[over:]
+		s.dropped += over
+	}
+}
+
+func (s *NetworkSink) loop() {
+	defer s.wg.Done()
+
+	backoff := 100 * time.Millisecond
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		lines, dropped, closed := s.queue, s.dropped, s.closed
+		s.queue, s.dropped = nil, 0
+		s.mu.Unlock()
+
+		if len(lines) == 0 {
+			return
+		}
+
+		if dropped > 0 {
+			reportSinkError(fmt.Errorf("golog: network sink dropped %d entries while %s was unreachable", dropped, s.address))
+		}
+
+		if err := s.send(lines); err != nil {
+			if closed {
+				reportSinkError(err)
+				return
+			}
+
+			// Put the lines back ahead of anything written meanwhile and
+			// wait before reconnecting.
+			s.mu.Lock()
+			pending := s.queue
+			s.queue = nil
+			s.enqueue(append(lines, pending...)...)
+			s.mu.Unlock()
+
+			select {
+			case <-time.After(backoff):
+			case <-s.done:
+			}
+			if backoff *= 2; backoff > 30*time.Second {
+				backoff = 30 * time.Second
+			}
+			continue
+		}
+		backoff = 100 * time.Millisecond
+	}
+}
+
+func (s *NetworkSink) send(lines []string) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	var err error
+	s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	if strings.HasPrefix(s.network, "udp") {
+		for _, line := range lines {
+			if _, err = s.conn.Write([]byte(line)); err != nil {
+				break
+			}
+		}
+	} else {
+		_, err = s.conn.Write([]byte(strings.Join(lines, "")))
+	}
+
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+// Close sends what is still queued, making one more attempt if the
+// collector is unreachable, and closes the connection.
+func (s *NetworkSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.cond.Signal()
+	s.mu.Unlock()
+
+	close(s.done)
+	s.wg.Wait()
+
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package golog_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestNetworkSinkReconnect(t *testing.T) {
+	// Find a free port, then leave it closed so the first dials fail.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := ln.Addr().String()
+	ln.Close()
+
+	sink, err := golog.NewNetworkSink(&golog.NetworkSinkOption{URL: "tcp://" + address, Format: golog.FormatJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for _, message := range []string{"first", "second"} {
+		if err := sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	ln, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	r := bufio.NewReader(conn)
+	for _, message := range []string{"first", "second"} {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(line, `"message":"`+message+`"`) {
+			t.Errorf("expected %s, got %q", message, line)
+		}
+	}
+}
+
+func TestNetworkSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := golog.NewNetworkSink(&golog.NetworkSinkOption{URL: "udp://" + conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{Level: golog.LWarning, Time: time.Date(2018, 7, 1, 9, 0, 0, 0, time.Local), Caller: "a.go:1", Message: "datagram"})
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "[  warn] 2018-07-01 09:00:00 (a.go:1): datagram\n" {
+		t.Errorf("unexpected datagram %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	newRelicEndpoint   = "https://log-api.newrelic.com/log/v1"
+	newRelicEUEndpoint = "https://log-api.eu.newrelic.com/log/v1"
+)
+
+type NewRelicSinkOption struct {
+	LicenseKey  string
+	ServiceName string
+
+	// Attributes are sent once per batch as common attributes.
+	Attributes map[string]interface{}
+
+	EU       bool
+	Endpoint string
+
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+	HTTPClient    *http.Client
+}
+
+// NewRelicSink ships entries to the New Relic Log API. The trace_id and
+// span_id fields are renamed to trace.id and span.id so New Relic links the
+// entries to distributed traces.
+type NewRelicSink struct {
+	endpoint   string
+	licenseKey string
+	common     map[string]interface{}
+	maxRetries int
+	client     *http.Client
+	batch      *batcher
+}
+
+type newRelicLog struct {
+	Timestamp  int64                  `json:"timestamp"`
+	Message    string                 `json:"message"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+var newRelicFieldNames = map[string]string{
+	"trace_id": "trace.id",
+	"span_id":  "span.id",
+}
+
+func NewNewRelicSink(option *NewRelicSinkOption) (*NewRelicSink, error) {
+	if option == nil || option.LicenseKey == "" {
+		return nil, errors.New("golog: new relic sink requires a license key")
+	}
+
+	s := &NewRelicSink{
+		endpoint:   option.Endpoint,
+		licenseKey: option.LicenseKey,
+		common:     make(map[string]interface{}),
+		maxRetries: option.MaxRetries,
+		client:     option.HTTPClient,
+	}
+	if s.endpoint == "" {
+		s.endpoint = newRelicEndpoint
+		if option.EU {
+			s.endpoint = newRelicEUEndpoint
+		}
+	}
+	for k, v := range option.Attributes {
+		s.common[k] = v
+	}
+	if option.ServiceName != "" {
+		s.common["service.name"] = option.ServiceName
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 5
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 1000
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	s.batch = newBatcher("new relic sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *NewRelicSink) Write(entry *Entry) error {
+	return s.batch.add(entry)
+}
+
+func (s *NewRelicSink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *NewRelicSink) Close() error {
+	return s.batch.close()
+}
+
+func (s *NewRelicSink) send(entries []Entry) error {
+	logs := make([]newRelicLog, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+
+		attributes := entry.values()
+		delete(attributes, "time")
+		delete(attributes, "message")
+		for from, to := range newRelicFieldNames {
+			if v, ok := attributes[from]; ok {
+				delete(attributes, from)
+				attributes[to] = v
+			}
+		}
+
+		logs[i] = newRelicLog{
+			Timestamp:  entry.Time.UnixNano() / int64(time.Millisecond),
+			Message:    entry.Message,
+			Attributes: attributes,
+		}
+	}
+
+	payload := []map[string]interface{}{{
+		"common": map[string]interface{}{"attributes": s.common},
+		"logs":   logs,
+	}}
+
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	if err := json.NewEncoder(zw).Encode(payload); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		req.Header.Set("X-License-Key", s.licenseKey)
+
+		return doRequest(s.client, req, "new relic log upload")
+	})
+}
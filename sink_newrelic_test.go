@@ -0,0 +1,67 @@
+package golog_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestNewRelicSink(t *testing.T) {
+	var payload []struct {
+		Common struct {
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"common"`
+		Logs []struct {
+			Message    string                 `json:"message"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"logs"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-License-Key") != "key" {
+			t.Errorf("unexpected license key %q", r.Header.Get("X-License-Key"))
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		json.NewDecoder(zr).Decode(&payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewNewRelicSink(&golog.NewRelicSinkOption{
+		LicenseKey:  "key",
+		ServiceName: "api",
+		Endpoint:    srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink.Write(&golog.Entry{
+		Level:   golog.LError,
+		Time:    time.Now(),
+		Message: "test",
+		Fields:  []golog.Field{{Key: "trace_id", Value: "abc"}},
+	})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload) != 1 || len(payload[0].Logs) != 1 {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if payload[0].Common.Attributes["service.name"] != "api" {
+		t.Errorf("service.name missing: %v", payload[0].Common.Attributes)
+	}
+	log := payload[0].Logs[0]
+	if log.Message != "test" || log.Attributes["trace.id"] != "abc" || log.Attributes["level"] != "error" {
+		t.Errorf("unexpected log %+v", log)
+	}
+}
@@ -0,0 +1,152 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ParquetSinkOption struct {
+	Dir           string
+	FlushInterval time.Duration
+	MaxEntries    int
+}
+
+// ParquetSink buffers entries and writes them as Parquet files laid out in
+// hive-style partitions (date=/hour=/level=) below Dir.
+type ParquetSink struct {
+	dir        string
+	maxEntries int
+
+	mu       sync.Mutex
+	buffered map[parquetPartition][]Entry
+	count    int
+	seq      uint64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type parquetPartition struct {
+	hour  time.Time
+	level Level
+}
+
+func NewParquetSink(option *ParquetSinkOption) (*ParquetSink, error) {
+	if option == nil || option.Dir == "" {
+		return nil, errors.New("golog: parquet sink requires a directory")
+	}
+
+	if err := os.MkdirAll(option.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &ParquetSink{
+		dir:        option.Dir,
+		maxEntries: option.MaxEntries,
+		buffered:   make(map[parquetPartition][]Entry),
+		done:       make(chan struct{}),
+	}
+	if s.maxEntries <= 0 {
+		s.maxEntries = 10000
+	}
+
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	s.wg.Add(1)
+	go s.flushLoop(interval)
+
+	return s, nil
+}
+
+func (s *ParquetSink) flushLoop(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				reportSinkError(err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *ParquetSink) Write(entry *Entry) error {
+	key := parquetPartition{
+		hour:  entry.Time.UTC().Truncate(time.Hour),
+		level: entry.Level,
+	}
+
+	s.mu.Lock()
+	s.buffered[key] = append(s.buffered[key], *entry)
+	s.count++
+	full := s.count >= s.maxEntries
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush()
+	}
+
+	return nil
+}
+
+func (s *ParquetSink) Flush() error {
+	s.mu.Lock()
+	buffered := s.buffered
+	s.buffered = make(map[parquetPartition][]Entry)
+	s.count = 0
+	s.mu.Unlock()
+
+	var firstErr error
+	for key, entries := range buffered {
+		if err := s.writeFile(key, entries); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s *ParquetSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+
+	return s.Flush()
+}
+
+func (s *ParquetSink) writeFile(key parquetPartition, entries []Entry) error {
+	dir := filepath.Join(s.dir,
+		"date="+key.hour.Format("2006-01-02"),
+		"hour="+key.hour.Format("15"),
+		"level="+trimLevel(key.level))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeParquetEntries(&buf, entries); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("part-%d-%d.parquet", time.Now().UnixNano(), atomic.AddUint64(&s.seq, 1))
+	tmp := filepath.Join(dir, "."+name+".tmp")
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(dir, name))
+}
@@ -0,0 +1,173 @@
+package golog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestParquetSink(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := golog.NewParquetSink(&golog.ParquetSinkOption{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2018, 7, 1, 13, 5, 0, 0, time.UTC)
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Caller: "a.go:1", Message: "first"})
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now.Add(time.Second), Caller: "a.go:2", Message: "second"})
+	sink.Write(&golog.Entry{Level: golog.LError, Time: now, Caller: "b.go:3", Message: "failed"})
+
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][][]interface{}{
+		"info": {
+			{now.UnixNano() / 1000, now.Add(time.Second).UnixNano() / 1000},
+			{"info", "info"},
+			{"a.go:1", "a.go:2"},
+			{"first", "second"},
+		},
+		"error": {
+			{now.UnixNano() / 1000},
+			{"error"},
+			{"b.go:3"},
+			{"failed"},
+		},
+	}
+
+	for level, columns := range expected {
+		files, _ := filepath.Glob(filepath.Join(dir, "date=2018-07-01", "hour=13", "level="+level, "*.parquet"))
+		if len(files) != 1 {
+			t.Fatalf("level=%s: expected 1 file, got %d", level, len(files))
+		}
+
+		data, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+			t.Fatalf("level=%s: not a parquet file", level)
+		}
+
+		metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+		meta := readThriftStruct(t, bytes.NewReader(data[len(data)-8-metaLen:len(data)-8]))
+
+		rows := len(columns[0])
+		if n := meta[3].(int64); n != int64(rows) {
+			t.Errorf("level=%s: expected %d rows, got %d", level, rows, n)
+		}
+
+		var names []string
+		for _, element := range meta[2].([]interface{})[1:] {
+			names = append(names, string(element.(map[int16]interface{})[4].([]byte)))
+		}
+		if !reflect.DeepEqual(names, []string{"time", "level", "caller", "message"}) {
+			t.Errorf("level=%s: unexpected schema %v", level, names)
+		}
+
+		group := meta[4].([]interface{})[0].(map[int16]interface{})
+		for i, chunk := range group[1].([]interface{}) {
+			column := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+			page := bytes.NewReader(data[column[9].(int64):])
+			header := readThriftStruct(t, page)
+
+			compressed := make([]byte, header[3].(int32))
+			io.ReadFull(page, compressed)
+			zr, err := gzip.NewReader(bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			plain, _ := io.ReadAll(zr)
+
+			values := readPlainValues(plain, column[1].(int32), rows)
+			if !reflect.DeepEqual(values, columns[i]) {
+				t.Errorf("level=%s: column %s holds %v, expected %v", level, names[i], values, columns[i])
+			}
+		}
+	}
+}
+
+// readPlainValues decodes PLAIN INT64 (2) or BYTE_ARRAY (6) values.
+func readPlainValues(plain []byte, physical int32, n int) []interface{} {
+	var values []interface{}
+	for i := 0; i < n; i++ {
+		if physical == 2 {
+			values = append(values, int64(binary.LittleEndian.Uint64(plain)))
+			plain = plain[8:]
+			continue
+		}
+		size := binary.LittleEndian.Uint32(plain)
+		values = append(values, string(plain[4:4+size]))
+		plain = plain[4+size:]
+	}
+
+	return values
+}
+
+// readThriftStruct decodes a Thrift compact protocol struct into a map keyed
+// by field id, covering the types the Parquet metadata uses.
+func readThriftStruct(t *testing.T, r *bytes.Reader) map[int16]interface{} {
+	t.Helper()
+
+	fields := make(map[int16]interface{})
+	var id int16
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b == 0 {
+			return fields
+		}
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, _ := binary.ReadVarint(r)
+			id = int16(v)
+		}
+		fields[id] = readThriftValue(t, r, b&0x0f)
+	}
+}
+
+func readThriftValue(t *testing.T, r *bytes.Reader, typ byte) interface{} {
+	switch typ {
+	case 5:
+		v, _ := binary.ReadVarint(r)
+		return int32(v)
+	case 6:
+		v, _ := binary.ReadVarint(r)
+		return v
+	case 8:
+		n, _ := binary.ReadUvarint(r)
+		b := make([]byte, n)
+		io.ReadFull(r, b)
+		return b
+	case 9:
+		header, _ := r.ReadByte()
+		n := uint64(header >> 4)
+		if n == 15 {
+			n, _ = binary.ReadUvarint(r)
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = readThriftValue(t, r, header&0x0f)
+		}
+		return list
+	case 12:
+		return readThriftStruct(t, r)
+	}
+
+	t.Fatalf("unexpected thrift type %d", typ)
+	return nil
+}
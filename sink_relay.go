@@ -0,0 +1,99 @@
+package golog
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+type RelaySinkOption struct {
+	// Network is "unix" or "tcp"; Address is the socket path or host:port
+	// gologd listens on.
+	Network string
+	Address string
+
+	DialTimeout time.Duration
+}
+
+// RelaySink streams entries to a gologd relay in the binary log format,
+// one stream per connection. A broken connection is redialed on the next
+// write; the entry that hit the error is reported and dropped.
+type RelaySink struct {
+	network string
+	address string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	enc  *binlogEncoder
+	buf  []byte
+}
+
+func NewRelaySink(option *RelaySinkOption) (*RelaySink, error) {
+	if option == nil || option.Network == "" || option.Address == "" {
+		return nil, errors.New("golog: relay sink requires a network and address")
+	}
+
+	s := &RelaySink{
+		network: option.Network,
+		address: option.Address,
+		timeout: option.DialTimeout,
+	}
+	if s.timeout <= 0 {
+		s.timeout = 5 * time.Second
+	}
+
+	return s, nil
+}
+
+func (s *RelaySink) dial() error {
+	conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte(binlogMagic)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	s.conn = conn
+	s.enc = newBinlogEncoder()
+
+	return nil
+}
+
+func (s *RelaySink) Write(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.dial(); err != nil {
+			return err
+		}
+	}
+
+	s.buf = s.enc.encode(s.buf[:0], entry)
+	if _, err := s.conn.Write(s.buf); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+func (s *RelaySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
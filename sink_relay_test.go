@@ -0,0 +1,60 @@
+package golog_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestRelaySink(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan *golog.Entry, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		br, err := golog.NewBinaryReader(conn)
+		if err != nil {
+			return
+		}
+		for {
+			entry, err := br.Next()
+			if err != nil {
+				return
+			}
+			received <- entry
+		}
+	}()
+
+	sink, err := golog.NewRelaySink(&golog.RelaySinkOption{Network: "tcp", Address: ln.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	now := time.Now()
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: now, Caller: "a.go:1", Message: "first"})
+	sink.Write(&golog.Entry{Level: golog.LError, Time: now, Caller: "a.go:2", Message: "second",
+		Fields: []golog.Field{{Key: "user", Value: "u1"}}})
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case entry := <-received:
+			if entry.Message != want || !entry.Time.Equal(now) {
+				t.Errorf("unexpected entry %+v", entry)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
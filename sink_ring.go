@@ -0,0 +1,195 @@
+package golog
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"sync"
+	"text/template"
+)
+
+// Ring file layout: a fixed header followed by the ring area. The header
+// holds the ring capacity and the total number of bytes ever written
+// (head). Each record is [len][crc][payload][len]; the trailing length lets
+// a reader walk backwards from head without knowing where the oldest,
+// partially overwritten record starts.
+const (
+	ringMagic      = "GLRING01"
+	ringHeaderSize = 64
+	ringRecordHead = 8
+	ringRecordTail = 4
+)
+
+type RingSinkOption struct {
+	Path string
+
+	// Size is the capacity of the ring in bytes. 1 MiB when 0.
+	Size int
+}
+
+// RingSink keeps the most recent entries in a memory-mapped ring file.
+// Records land in the page cache as soon as they are copied, so they
+// survive the process being killed; use ReadRingFile to extract them.
+type RingSink struct {
+	mu       sync.Mutex
+	file     *os.File
+	data     []byte
+	capacity uint64
+	head     uint64
+	header   *template.Template
+}
+
+func NewRingSink(option *RingSinkOption) (*RingSink, error) {
+	if option == nil || option.Path == "" {
+		return nil, errors.New("golog: ring sink requires a path")
+	}
+
+	capacity := option.Size
+	if capacity <= 0 {
+		capacity = 1 << 20
+	}
+
+	file, err := os.OpenFile(option.Path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	size := ringHeaderSize + capacity
+	if err := file.Truncate(int64(size)); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	data, err := mmapFile(file, size)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	s := &RingSink{
+		file:     file,
+		data:     data,
+		capacity: uint64(capacity),
+		header:   newDefaultHeader(),
+	}
+
+	// Keep what a previous process left behind if the layout matches.
+	if string(data[:8]) == ringMagic && binary.LittleEndian.Uint64(data[8:]) == s.capacity {
+		s.head = binary.LittleEndian.Uint64(data[16:])
+	} else {
+		copy(data, ringMagic)
+		binary.LittleEndian.PutUint64(data[8:], s.capacity)
+		binary.LittleEndian.PutUint64(data[16:], 0)
+	}
+
+	return s, nil
+}
+
+func (s *RingSink) Write(entry *Entry) error {
+	payload := []byte(renderHeader(s.header, entry, lineStyle{}) + entry.textMessage() + entry.stackSuffix())
+	if max := int(s.capacity) - ringRecordHead - ringRecordTail; len(payload) > max {
+		payload = payload[:max]
+	}
+
+	record := make([]byte, ringRecordHead+len(payload)+ringRecordTail)
+	binary.LittleEndian.PutUint32(record[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload))
+	copy(record[ringRecordHead:], payload)
+	binary.LittleEndian.PutUint32(record[len(record)-ringRecordTail:], uint32(len(payload)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		return errors.New("golog: ring sink is closed")
+	}
+
+	ring := s.data[ringHeaderSize:]
+	pos := s.head % s.capacity
+	n := copy(ring[pos:], record)
+	copy(ring, record[n:])
+
+	// Publishing head last means a record torn by a crash is never visible.
+	s.head += uint64(len(record))
+	binary.LittleEndian.PutUint64(s.data[16:], s.head)
+
+	return nil
+}
+
+func (s *RingSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		return nil
+	}
+
+	err := munmapFile(s.data)
+	s.data = nil
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// ReadRingFile returns the records retained in a ring file written by a
+// RingSink, oldest first. It reads the file directly, so it works while the
+// writing process is alive or after it crashed.
+func ReadRingFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < ringHeaderSize || string(data[:8]) != ringMagic {
+		return nil, errors.New("golog: not a ring file")
+	}
+
+	capacity := binary.LittleEndian.Uint64(data[8:])
+	head := binary.LittleEndian.Uint64(data[16:])
+	if uint64(len(data)) < ringHeaderSize+capacity {
+		return nil, errors.New("golog: ring file is truncated")
+	}
+	ring := data[ringHeaderSize : ringHeaderSize+capacity]
+
+	read := func(offset uint64, n int) []byte {
+		buf := make([]byte, n)
+		pos := offset % capacity
+		copied := copy(buf, ring[pos:])
+		copy(buf[copied:], ring)
+		return buf
+	}
+
+	var oldest uint64
+	if head > capacity {
+		oldest = head - capacity
+	}
+
+	var records []string
+	for end := head; end >= oldest+ringRecordHead+ringRecordTail; {
+		size := uint64(binary.LittleEndian.Uint32(read(end-ringRecordTail, ringRecordTail)))
+		total := size + ringRecordHead + ringRecordTail
+		if total > end-oldest {
+			break
+		}
+
+		start := end - total
+		record := read(start, int(total))
+		payload := record[ringRecordHead : ringRecordHead+size]
+		if uint64(binary.LittleEndian.Uint32(record)) != size ||
+			binary.LittleEndian.Uint32(record[4:]) != crc32.ChecksumIEEE(payload) {
+			break
+		}
+
+		records = append(records, string(payload))
+		end = start
+	}
+
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+
+	return records, nil
+}
@@ -0,0 +1,78 @@
+package golog_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestRingSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring.log")
+
+	sink, err := golog.NewRingSink(&golog.RingSinkOption{Path: path, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: fmt.Sprintf("message %d", i)})
+	}
+
+	// The ring is readable while the sink is still open, as after a crash.
+	records, err := golog.ReadRingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || len(records) >= 100 {
+		t.Fatalf("unexpected record count %d", len(records))
+	}
+	if !strings.HasSuffix(records[len(records)-1], "message 99") {
+		t.Errorf("newest record is %q", records[len(records)-1])
+	}
+	for i := 1; i < len(records); i++ {
+		var prev, cur int
+		fmt.Sscanf(records[i-1][strings.LastIndex(records[i-1], " ")+1:], "%d", &prev)
+		fmt.Sscanf(records[i][strings.LastIndex(records[i], " ")+1:], "%d", &cur)
+		if cur != prev+1 {
+			t.Fatalf("records out of order: %q then %q", records[i-1], records[i])
+		}
+	}
+	sink.Close()
+
+	// Reopening keeps the previous contents.
+	sink, err = golog.NewRingSink(&golog.RingSinkOption{Path: path, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Caller: "a.go:1", Message: "message 100"})
+	sink.Close()
+
+	records, _ = golog.ReadRingFile(path)
+	if !strings.HasSuffix(records[len(records)-1], "message 100") || !strings.HasSuffix(records[len(records)-2], "message 99") {
+		t.Errorf("previous records lost: %q", records[len(records)-2:])
+	}
+}
+
+func TestRingSinkFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring.log")
+
+	sink, err := golog.NewRingSink(&golog.RingSinkOption{Path: path, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{Level: golog.LError, Time: time.Now(), Caller: "a.go:1", Message: "failed", Fields: []golog.Field{{Key: "user", Value: 7}}})
+
+	records, err := golog.ReadRingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || !strings.HasSuffix(records[0], "failed user=7") {
+		t.Errorf("unexpected records %q", records)
+	}
+}
@@ -0,0 +1,278 @@
+package golog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SentrySinkOption struct {
+	// DSN is the project's client key, https://KEY@HOST/PROJECT_ID.
+	DSN string
+
+	Environment string
+	Release     string
+
+	// MinLevel is the lowest level sent; LError when unset.
+	MinLevel Level
+
+	MaxBatch      int
+	FlushInterval time.Duration
+	MaxRetries    int
+	HTTPClient    *http.Client
+}
+
+// SentrySink reports entries to Sentry as events. The "stacktrace" field,
+// or else the caller, becomes the exception's stack trace so Sentry can
+// group the events, and the other fields become tags. LDPanic and LPanic
+// entries are sent before Write returns, since the process may be about to
+// exit.
+type SentrySink struct {
+	url         string
+	auth        string
+	environment string
+	release     string
+	minLevel    Level
+	hostname    string
+	maxRetries  int
+	client      *http.Client
+	batch       *batcher
+}
+
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	Platform    string            `json:"platform"`
+	Message     string            `json:"message"`
+	Culprit     string            `json:"culprit,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Exception   []sentryException `json:"exception,omitempty"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+}
+
+func NewSentrySink(option *SentrySinkOption) (*SentrySink, error) {
+	if option == nil || option.DSN == "" {
+		return nil, errors.New("golog: sentry sink requires a dsn")
+	}
+
+	dsn, err := url.Parse(option.DSN)
+	if err != nil {
+		return nil, err
+	}
+	key := dsn.User.Username()
+	dir, project := path.Split(strings.TrimRight(dsn.Path, "/"))
+	if key == "" || project == "" {
+		return nil, fmt.Errorf("golog: sentry dsn %q lacks a key or project id", option.DSN)
+	}
+
+	s := &SentrySink{
+		url:         fmt.Sprintf("%s://%s%sapi/%s/store/", dsn.Scheme, dsn.Host, dir, project),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=golog, sentry_key=%s", key),
+		environment: option.Environment,
+		release:     option.Release,
+		minLevel:    option.MinLevel,
+		maxRetries:  option.MaxRetries,
+		client:      option.HTTPClient,
+	}
+	if s.minLevel == unknownLevel {
+		s.minLevel = LError
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 3
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+	s.hostname, _ = os.Hostname()
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 10
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s.batch = newBatcher("sentry sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *SentrySink) Write(entry *Entry) error {
+	if entry.Level < s.minLevel {
+		return nil
+	}
+
+	if err := s.batch.add(entry); err != nil {
+		return err
+	}
+	if entry.Level >= LDPanic {
+		return s.batch.Flush()
+	}
+
+	return nil
+}
+
+func (s *SentrySink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *SentrySink) Close() error {
+	return s.batch.close()
+}
+
+func sentryLevel(level Level) string {
+	switch {
+	case level < LInfo:
+		return "debug"
+	case level < LWarning:
+		return "info"
+	case level < LError:
+		return "warning"
+	case level < LDPanic:
+		return "error"
+	}
+
+	return "fatal"
+}
+
+func (s *SentrySink) event(entry *Entry) sentryEvent {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	event := sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   entry.Time.UTC().Format(time.RFC3339Nano),
+		Level:       sentryLevel(entry.Level),
+		Logger:      "golog",
+		Platform:    "go",
+		Message:     entry.Message,
+		Culprit:     entry.Function,
+		ServerName:  s.hostname,
+		Environment: s.environment,
+		Release:     s.release,
+		Tags:        map[string]string{"caller": entry.Caller},
+	}
+
+	exception := sentryException{Type: "error", Value: entry.Message}
+	for _, field := range entry.Fields {
+		switch field.Key {
+		case "stacktrace":
+			if stack, ok := field.Value.(string); ok {
+				exception.Stacktrace.Frames = parseStackFrames(stack)
+			}
+		case "panic_type":
+			exception.Type = fmt.Sprint(field.Value)
+			event.Tags[field.Key] = exception.Type
+		default:
+			event.Tags[field.Key] = fmt.Sprint(field.Value)
+		}
+	}
+	if len(exception.Stacktrace.Frames) == 0 && entry.File != "" {
+		exception.Stacktrace.Frames = []sentryFrame{{
+			Function: entry.Function,
+			Filename: path.Base(entry.File),
+			AbsPath:  entry.File,
+			Lineno:   entry.Line,
+		}}
+	}
+	if len(exception.Stacktrace.Frames) > 0 {
+		event.Exception = []sentryException{exception}
+	}
+
+	return event
+}
+
+// parseStackFrames reads a stack rendered as function lines each followed
+// by a tab-indented file:line, innermost first, into Sentry frames, which
+// are listed outermost first.
+func parseStackFrames(stack string) []sentryFrame {
+	var frames []sentryFrame
+	var function string
+	for _, line := range strings.Split(stack, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			function = strings.TrimSpace(line)
+			continue
+		}
+
+		location := strings.TrimSpace(line)
+		if i := strings.LastIndex(location, " +0x"); i >= 0 {
+			location = location[:i]
+		}
+		i := strings.LastIndex(location, ":")
+		if i < 0 {
+			continue
+		}
+		lineno, _ := strconv.Atoi(location[i+1:])
+		frames = append(frames, sentryFrame{
+			Function: function,
+			Filename: path.Base(location[:i]),
+			AbsPath:  location[:i],
+			Lineno:   lineno,
+		})
+		function = ""
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+
+	return frames
+}
+
+func (s *SentrySink) send(entries []Entry) error {
+	var firstErr error
+	for i := range entries {
+		body, err := json.Marshal(s.event(&entries[i]))
+		if err != nil {
+			return err
+		}
+
+		err = retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+			req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+			if err != nil {
+				return false, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Sentry-Auth", s.auth)
+
+			return doRequest(s.client, req, "sentry event")
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
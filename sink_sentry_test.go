@@ -0,0 +1,81 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSentrySink(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/42/store/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("X-Sentry-Auth"); !strings.Contains(auth, "sentry_key=public") {
+			t.Errorf("unexpected auth header %q", auth)
+		}
+
+		var event map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewSentrySink(&golog.SentrySinkOption{
+		DSN:         strings.Replace(srv.URL, "://", "://public@", 1) + "/42",
+		Environment: "prod",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{Level: golog.LWarning, Time: time.Now(), Message: "ignored"})
+	sink.Write(&golog.Entry{
+		Level:    golog.LPanic,
+		Time:     time.Now(),
+		Caller:   "db.go:12",
+		Message:  "panic: boom",
+		File:     "/src/db.go",
+		Line:     12,
+		Function: "main.query",
+		Fields: []golog.Field{
+			{Key: "panic_type", Value: "*errors.errorString"},
+			{Key: "user", Value: 42},
+			{Key: "stacktrace", Value: "main.query\n\t/src/db.go:12\nmain.main\n\t/src/main.go:5 +0x1d"},
+		},
+	})
+
+	// Panics are sent before Write returns.
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	tags := event["tags"].(map[string]interface{})
+	if event["level"] != "fatal" || event["message"] != "panic: boom" || event["environment"] != "prod" || tags["user"] != "42" || tags["caller"] != "db.go:12" {
+		t.Errorf("unexpected event %v", event)
+	}
+
+	exception := event["exception"].([]interface{})[0].(map[string]interface{})
+	frames := exception["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+	if exception["type"] != "*errors.errorString" || len(frames) != 2 {
+		t.Fatalf("unexpected exception %v", exception)
+	}
+	if frame := frames[0].(map[string]interface{}); frame["function"] != "main.main" || frame["abs_path"] != "/src/main.go" || frame["lineno"] != 5.0 {
+		t.Errorf("unexpected outermost frame %v", frame)
+	}
+}
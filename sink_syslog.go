@@ -0,0 +1,222 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type SyslogFormat uint8
+
+const (
+	SyslogRFC3164 SyslogFormat = iota
+	SyslogRFC5424
+)
+
+// SyslogFacility is a syslog facility code.
+type SyslogFacility uint8
+
+const (
+	FacilityUser   SyslogFacility = 1
+	FacilityDaemon SyslogFacility = 3
+	FacilityLocal0 SyslogFacility = 16
+	FacilityLocal1 SyslogFacility = 17
+	FacilityLocal2 SyslogFacility = 18
+	FacilityLocal3 SyslogFacility = 19
+	FacilityLocal4 SyslogFacility = 20
+	FacilityLocal5 SyslogFacility = 21
+	FacilityLocal6 SyslogFacility = 22
+	FacilityLocal7 SyslogFacility = 23
+)
+
+type SyslogSinkOption struct {
+	// Network is "udp", "tcp" or "unix"/"unixgram" with Address the
+	// endpoint. Leave both empty for the local syslog socket (/dev/log).
+	Network string
+	Address string
+
+	Format SyslogFormat
+
+	// Facility defaults to FacilityUser.
+	Facility SyslogFacility
+
+	// Tag is the app name; it defaults to the program name.
+	Tag string
+
+	DialTimeout time.Duration
+}
+
+// SyslogSink sends entries to a syslog daemon, mapping levels to syslog
+// severities. Stream connections frame messages with a newline (RFC 3164)
+// or an octet count (RFC 5424, per RFC 6587). A broken connection is
+// redialed on the next write.
+type SyslogSink struct {
+	network  string
+	address  string
+	format   SyslogFormat
+	facility SyslogFacility
+	tag      string
+	hostname string
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	stream bool
+	local  bool
+}
+
+func NewSyslogSink(option *SyslogSinkOption) (*SyslogSink, error) {
+	if option == nil {
+		option = &SyslogSinkOption{}
+	}
+	if (option.Network == "") != (option.Address == "") {
+		return nil, errors.New("golog: syslog sink requires both a network and address, or neither")
+	}
+
+	s := &SyslogSink{
+		network:  option.Network,
+		address:  option.Address,
+		format:   option.Format,
+		facility: option.Facility,
+		tag:      option.Tag,
+		timeout:  option.DialTimeout,
+	}
+	if s.facility == 0 {
+		s.facility = FacilityUser
+	}
+	if s.tag == "" {
+		s.tag = filepath.Base(os.Args[0])
+	}
+	if s.timeout <= 0 {
+		s.timeout = 5 * time.Second
+	}
+	s.hostname, _ = os.Hostname()
+	if s.hostname == "" {
+		s.hostname = "-"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.dial(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SyslogSink) dial() error {
+	if s.network != "" {
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+		s.stream = s.network != "udp" && s.network != "udp4" && s.network != "udp6" && s.network != "unixgram"
+		return nil
+	}
+
+	for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.DialTimeout(network, path, s.timeout); err == nil {
+				s.conn = conn
+				s.stream = network == "unix"
+				s.local = true
+				return nil
+			}
+		}
+	}
+
+	return errors.New("golog: no local syslog socket found")
+}
+
+// syslogSeverity maps a level to a syslog severity.
+func syslogSeverity(level Level) int {
+	switch level {
+	case LTrace, LDebug:
+		return 7
+	case LInfo:
+		return 6
+	case LNotice:
+		return 5
+	case LWarning:
+		return 4
+	case LError:
+		return 3
+	case LDPanic:
+		return 2
+	case LPanic, LFatal:
+		return 1
+	}
+	if custom, ok := lookupLevel(level); ok {
+		return custom.syslog
+	}
+
+	return 6
+}
+
+func (s *SyslogSink) message(entry *Entry) string {
+	pri := int(s.facility)*8 + syslogSeverity(entry.Level)
+	msg := entry.Caller + ": " + entry.textMessage()
+	pid := os.Getpid()
+
+	if s.format == SyslogRFC5424 {
+		return fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+			pri, entry.Time.Format("2006-01-02T15:04:05.000000Z07:00"), s.hostname, s.tag, pid, msg)
+	}
+
+	// The local daemon adds the hostname itself.
+	if s.local {
+		return fmt.Sprintf("<%d>%s %s[%d]: %s", pri, entry.Time.Format(time.Stamp), s.tag, pid, msg)
+	}
+
+	return fmt.Sprintf("<%d>%s %s %s[%d]: %s", pri, entry.Time.Format(time.Stamp), s.hostname, s.tag, pid, msg)
+}
+
+func (s *SyslogSink) Write(entry *Entry) error {
+	msg := s.message(entry)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.dial(); err != nil {
+			return err
+		}
+	}
+
+	if s.stream {
+		if s.format == SyslogRFC5424 {
+			msg = strconv.Itoa(len(msg)) + " " + msg
+		} else {
+			msg += "\n"
+		}
+	}
+
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
@@ -0,0 +1,100 @@
+package golog_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := golog.NewSyslogSink(&golog.SyslogSinkOption{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Facility: golog.FacilityLocal3,
+		Tag:      "app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	entry := &golog.Entry{Level: golog.LError, Time: time.Date(2018, 7, 1, 9, 5, 0, 0, time.Local), Caller: "a.go:1", Message: "failed",
+		Fields: []golog.Field{{Key: "user", Value: 42}}}
+	if err := sink.Write(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// local3 * 8 + err
+	got := string(buf[:n])
+	if !strings.HasPrefix(got, "<155>Jul  1 09:05:00 ") || !strings.Contains(got, " app[") || !strings.HasSuffix(got, "]: a.go:1: failed user=42") {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestSyslogSinkTCP5424(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	sink, err := golog.NewSyslogSink(&golog.SyslogSinkOption{
+		Network: "tcp",
+		Address: ln.Addr().String(),
+		Format:  golog.SyslogRFC5424,
+		Tag:     "app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, level := range []golog.Level{golog.LDebug, golog.LWarning} {
+		sink.Write(&golog.Entry{Level: level, Time: time.Now(), Caller: "a.go:1", Message: "m"})
+	}
+
+	r := bufio.NewReader(conn)
+	for _, pri := range []string{"<15>1 ", "<12>1 "} {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			t.Fatal(err)
+		}
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(r, frame); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(frame), pri) || !strings.HasSuffix(string(frame), " app "+strconv.Itoa(os.Getpid())+" - - a.go:1: m") {
+			t.Errorf("unexpected frame %q", frame)
+		}
+	}
+}
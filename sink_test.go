@@ -0,0 +1,29 @@
+package golog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestAddSinkLevel(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	console := &memorySink{}
+	shadow := &memorySink{}
+	golog.Std.AddSink(console)
+	golog.Std.AddSinkLevel(shadow, golog.LTrace)
+
+	golog.Trace("trace")
+	golog.Debug("debug")
+	golog.Info("info")
+
+	if got, want := console.messages(), []string{"info"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("console: got %q, want %q", got, want)
+	}
+	if got, want := shadow.messages(), []string{"trace", "debug", "info"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("shadow: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// defaultWebhookTemplate is a Slack incoming webhook payload.
+const defaultWebhookTemplate = `{"text": {{json .Text}}}`
+
+type WebhookSinkOption struct {
+	URL string
+
+	// MinLevel is the lowest level posted; LError when unset.
+	MinLevel Level
+
+	// Template renders the request body from WebhookParam. The json
+	// function encodes a value as JSON. The default posts {"text": ...},
+	// which Slack and compatible chat tools accept.
+	Template string
+
+	// Entries are collected for FlushInterval (10s when 0), or until
+	// MaxBatch (20 when 0) are pending, and posted together.
+	MaxBatch      int
+	FlushInterval time.Duration
+
+	// At most MaxPosts requests (6 when 0) are made per Period (a minute
+	// when 0). Entries that would exceed that are dropped and counted in
+	// the next post.
+	MaxPosts int
+	Period   time.Duration
+
+	MaxRetries int
+	HTTPClient *http.Client
+}
+
+// WebhookParam is passed to WebhookSinkOption.Template.
+type WebhookParam struct {
+	Entries []Entry
+
+	// Text has one "[level] caller: message" line per entry, followed by
+	// a note about dropped entries if there were any.
+	Text string
+
+	// Dropped counts the entries lost to rate limiting since the last post.
+	Dropped int
+}
+
+// WebhookSink posts entries to a webhook, batched and rate limited so a
+// crash loop produces a handful of messages rather than a flood.
+type WebhookSink struct {
+	url        string
+	minLevel   Level
+	template   *texttemplate.Template
+	maxPosts   int
+	period     time.Duration
+	maxRetries int
+	client     *http.Client
+	batch      *batcher
+
+	mu      sync.Mutex
+	posts   []time.Time
+	dropped int
+}
+
+func NewWebhookSink(option *WebhookSinkOption) (*WebhookSink, error) {
+	if option == nil || option.URL == "" {
+		return nil, errors.New("golog: webhook sink requires a url")
+	}
+
+	tmplStr := option.Template
+	if tmplStr == "" {
+		tmplStr = defaultWebhookTemplate
+	}
+	tmpl, err := texttemplate.New("GoLogWebhookTemplate").Funcs(texttemplate.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(tmplStr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &WebhookSink{
+		url:        option.URL,
+		minLevel:   option.MinLevel,
+		template:   tmpl,
+		maxPosts:   option.MaxPosts,
+		period:     option.Period,
+		maxRetries: option.MaxRetries,
+		client:     option.HTTPClient,
+	}
+	if s.minLevel == unknownLevel {
+		s.minLevel = LError
+	}
+	if s.maxPosts <= 0 {
+		s.maxPosts = 6
+	}
+	if s.period <= 0 {
+		s.period = time.Minute
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = 3
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	maxBatch := option.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = 20
+	}
+	interval := option.FlushInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	s.batch = newBatcher("webhook sink", maxBatch, interval, s.send)
+
+	return s, nil
+}
+
+func (s *WebhookSink) Write(entry *Entry) error {
+	if entry.Level < s.minLevel {
+		return nil
+	}
+
+	return s.batch.add(entry)
+}
+
+func (s *WebhookSink) Flush() error {
+	return s.batch.Flush()
+}
+
+func (s *WebhookSink) Close() error {
+	return s.batch.close()
+}
+
+// allow records a post if the rate limit permits one now, and otherwise
+// counts the entries as dropped. It returns the drops to report.
+func (s *WebhookSink) allow(entries int) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	i := 0
+	for i < len(s.posts) && now.Sub(s.posts[i]) >= s.period {
+		i++
+	}
+	s.posts = s.posts[i:]
+
+	if len(s.posts) >= s.maxPosts {
+		s.dropped += entries
+		return 0, false
+	}
+	s.posts = append(s.posts, now)
+
+	dropped := s.dropped
+	s.dropped = 0
+
+	return dropped, true
+}
+
+func (s *WebhookSink) send(entries []Entry) error {
+	dropped, ok := s.allow(len(entries))
+	if !ok {
+		return nil
+	}
+
+	param := WebhookParam{Entries: entries, Dropped: dropped}
+	var text strings.Builder
+	for i, entry := range entries {
+		if i > 0 {
+			text.WriteByte('\n')
+		}
+		fmt.Fprintf(&text, "[%s] %s: %s", trimLevel(entry.Level), entry.Caller, entry.Message)
+	}
+	if dropped > 0 {
+		fmt.Fprintf(&text, "\n(%d more entries dropped by rate limiting)", dropped)
+	}
+	param.Text = text.String()
+
+	var body bytes.Buffer
+	if err := s.template.Execute(&body, param); err != nil {
+		return err
+	}
+
+	return retryBackoff(s.maxRetries, time.Second, func() (bool, error) {
+		req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		return doRequest(s.client, req, "webhook post")
+	})
+}
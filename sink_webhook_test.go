@@ -0,0 +1,84 @@
+package golog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var texts []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		texts = append(texts, payload.Text)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewWebhookSink(&golog.WebhookSinkOption{
+		URL:      srv.URL,
+		MaxBatch: 2,
+		MaxPosts: 1,
+		Period:   200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(level golog.Level, message string) {
+		sink.Write(&golog.Entry{Level: level, Time: time.Now(), Caller: "a.go:1", Message: message})
+	}
+	write(golog.LWarning, "below the threshold")
+	write(golog.LError, "first \"quoted\"")
+	write(golog.LPanic, "second")
+	write(golog.LError, "rate limited")
+	write(golog.LError, "also rate limited")
+	time.Sleep(300 * time.Millisecond)
+	write(golog.LError, "after the period")
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"[error] a.go:1: first \"quoted\"\n[panic] a.go:1: second",
+		"[error] a.go:1: after the period\n(2 more entries dropped by rate limiting)",
+	}
+	if len(texts) != len(want) || texts[0] != want[0] || texts[1] != want[1] {
+		t.Errorf("unexpected posts %q", texts)
+	}
+}
+
+func TestWebhookSinkSlowBackend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	sink, err := golog.NewWebhookSink(&golog.WebhookSinkOption{URL: srv.URL, MaxBatch: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	// Full batches are posted in the background, not by Write.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		sink.Write(&golog.Entry{Level: golog.LError, Time: time.Now(), Caller: "a.go:1", Message: "failed"})
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("writes blocked for %v", elapsed)
+	}
+}
@@ -0,0 +1,65 @@
+package golog
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"text/template"
+)
+
+type WriterSinkOption struct {
+	Writer io.Writer
+
+	// Format of the lines written; text lines use the default header.
+	Format Format
+
+	// Colorize writes ANSI colors styled as ColorMode says, whether or not
+	// Writer is a terminal.
+	Colorize  bool
+	ColorMode ColorMode
+	Theme     Theme
+}
+
+// WriterSink writes entries to any io.Writer with its own format, so one
+// logger can fan out to, say, colored text on the console and JSON in a
+// pipe or socket. Unlike wrapping outputs in io.MultiWriter, every sink
+// encodes the entry its own way. Writes are serialized; the writer is not
+// closed by Close.
+type WriterSink struct {
+	w      io.Writer
+	format Format
+	header *template.Template
+	style  lineStyle
+
+	mu sync.Mutex
+}
+
+func NewWriterSink(option *WriterSinkOption) (*WriterSink, error) {
+	if option == nil || option.Writer == nil {
+		return nil, errors.New("golog: writer sink requires a writer")
+	}
+
+	return &WriterSink{
+		w:      option.Writer,
+		format: option.Format,
+		header: newDefaultHeader(),
+		style:  lineStyle{colorize: option.Colorize, mode: option.ColorMode, colors: option.Theme, force: true},
+	}, nil
+}
+
+func (s *WriterSink) Write(entry *Entry) error {
+	line, ok := s.format.encode(entry)
+	if !ok {
+		line = formatLine(renderHeader(s.header, entry, s.style), entry.textMessage(), entry.Level, s.style) + entry.stackSuffix()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := io.WriteString(s.w, line+"\n")
+	return err
+}
+
+func (s *WriterSink) Close() error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package golog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWriterSinkFanOut(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	var console bytes.Buffer
+	text, err := golog.NewWriterSink(&golog.WriterSinkOption{Writer: &console, Colorize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := golog.NewFileSink(&golog.FileSinkOption{Path: path, Format: golog.FormatJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golog.Std.AddSink(text)
+	golog.Std.AddSink(file)
+
+	golog.Error("failed")
+	golog.Std.Close()
+
+	if line := console.String(); !strings.Contains(line, "\x1b[31m error\x1b[0m") || !strings.HasSuffix(line, ": failed\n") {
+		t.Errorf("unexpected console line %q", line)
+	}
+
+	data, _ := os.ReadFile(path)
+	var record map[string]interface{}
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("%v: %q", err, data)
+	}
+	if record["level"] != "error" || record["message"] != "failed" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
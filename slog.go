@@ -0,0 +1,99 @@
+package golog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that writes records through a GoLog, with
+// its header, colors, level filtering and sinks. Attributes become fields;
+// attributes in groups are keyed "group.key".
+type SlogHandler struct {
+	gl     *GoLog
+	fields []Field
+	group  string
+}
+
+// NewSlogHandler returns a handler for slog.New:
+//
+//	slog.SetDefault(slog.New(golog.NewSlogHandler(golog.Std)))
+func NewSlogHandler(gl *GoLog) *SlogHandler {
+	return &SlogHandler{gl: gl}
+}
+
+// slogLevel maps a slog level to the golog level at or below it.
+func slogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LTrace
+	case level < slog.LevelInfo:
+		return LDebug
+	case level == slog.LevelInfo:
+		return LInfo
+	case level < slog.LevelWarn:
+		return LNotice
+	case level < slog.LevelError:
+		return LWarning
+	}
+
+	return LError
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.gl.Enabled(slogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	entry := h.gl.newEntryAt(r.PC, slogLevel(r.Level), "%s", []interface{}{r.Message})
+	if !r.Time.IsZero() {
+		entry.Time = r.Time
+	}
+
+	entry.Fields = append(entry.Fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		entry.Fields = appendSlogAttr(entry.Fields, h.group, a)
+		return true
+	})
+
+	h.gl.writeCtx(ctx, entry)
+
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := append([]Field(nil), h.fields...)
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.group, a)
+	}
+
+	return &SlogHandler{gl: h.gl, fields: fields, group: h.group}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &SlogHandler{gl: h.gl, fields: h.fields, group: h.group + name + "."}
+}
+
+// appendSlogAttr flattens a into fields, following the slog.Handler rules:
+// empty attributes are dropped and groups without a key are inlined.
+func appendSlogAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+
+	return append(fields, Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
@@ -0,0 +1,48 @@
+package golog_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestSlogHandler(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	logger := slog.New(golog.NewSlogHandler(golog.Std)).With("service", "api")
+	logger.Debug("dropped")
+	logger.WithGroup("req").Warn("slow request", "path", "/users", slog.Group("timing", "ms", 350), slog.Group("", "inline", true))
+	logger.Info("100% done")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+
+	entry := sink.entries[0]
+	if entry.Level != golog.LWarning || entry.Message != "slow request" || entry.Caller != "slog_test.go:19" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	want := []golog.Field{
+		{Key: "service", Value: "api"},
+		{Key: "req.path", Value: "/users"},
+		{Key: "req.timing.ms", Value: int64(350)},
+		{Key: "req.inline", Value: true},
+	}
+	if len(entry.Fields) != len(want) {
+		t.Fatalf("unexpected fields %+v", entry.Fields)
+	}
+	for i, field := range want {
+		if entry.Fields[i] != field {
+			t.Errorf("field %d: got %+v, want %+v", i, entry.Fields[i], field)
+		}
+	}
+
+	if entry := sink.entries[1]; entry.Message != "100% done" || len(entry.Fields) != 1 {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,36 @@
+package golog
+
+import "time"
+
+// WarnIfSlow starts timing an operation; the returned function logs a
+// warning with the elapsed time if the operation took longer than
+// threshold, and nothing otherwise:
+//
+//	done := golog.WarnIfSlow("query users", 200*time.Millisecond)
+//	defer done()
+func WarnIfSlow(operation string, threshold time.Duration) (done func()) {
+	logger := getCurrentLogger()
+	start := logger.now()
+
+	var site Entry
+	setCaller(&site, 2)
+
+	return func() {
+		elapsed := logger.now().Sub(start)
+		if elapsed <= threshold {
+			return
+		}
+
+		entry := logger.newEntry(LWarning, "%s took %s (threshold %s)", []interface{}{operation, elapsed, threshold})
+		if entry == nil {
+			return
+		}
+		entry.Caller, entry.File, entry.Line, entry.Function = site.Caller, site.File, site.Line, site.Function
+		logger.config().formatCaller(entry)
+		entry.Fields = append(entry.Fields,
+			Field{Key: "operation", Value: operation},
+			Field{Key: "elapsed", Value: elapsed.String()})
+
+		logger.write(entry)
+	}
+}
@@ -0,0 +1,38 @@
+package golog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWarnIfSlow(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	now := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	golog.Std.SetClock(func() time.Time { return now })
+
+	done := golog.WarnIfSlow("fast", 200*time.Millisecond)
+	now = now.Add(100 * time.Millisecond)
+	done()
+
+	done = golog.WarnIfSlow("query users", 200*time.Millisecond)
+	now = now.Add(350 * time.Millisecond)
+	done()
+
+	if len(sink.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(sink.entries))
+	}
+	entry := sink.entries[0]
+	if entry.Level != golog.LWarning || entry.Message != "query users took 350ms (threshold 200ms)" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.Caller != "slow_test.go:24" {
+		t.Errorf("expected the WarnIfSlow call site, got %s", entry.Caller)
+	}
+}
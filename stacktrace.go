@@ -0,0 +1,76 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errorStack renders the stack carried by err or an error it wraps, taken
+// from a StackTrace method as provided by github.com/pkg/errors and
+// compatible libraries. The innermost stack is used since it is the one
+// closest to where the error originated. The method's result type is
+// library specific, so it is found by reflection and rendered with %+v.
+func errorStack(err error) (string, bool) {
+	var method reflect.Value
+	for e := err; e != nil; e = unwrapError(e) {
+		m := reflect.ValueOf(e).MethodByName("StackTrace")
+		if m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+			method = m
+		}
+	}
+
+	if !method.IsValid() {
+		return "", false
+	}
+
+	stack := strings.Trim(fmt.Sprintf("%+v", method.Call(nil)[0].Interface()), "\n")
+	return stack, stack != ""
+}
+
+func unwrapError(err error) error {
+	if next := errors.Unwrap(err); next != nil {
+		return next
+	}
+
+	// pkg/errors before v0.9 only offers Cause.
+	if causer, ok := err.(interface{ Cause() error }); ok && causer.Cause() != err {
+		return causer.Cause()
+	}
+
+	return nil
+}
+
+// attachErrorStack adds a "stacktrace" field for the first error among
+// args and fields that carries a stack.
+func (entry *Entry) attachErrorStack(args []interface{}) {
+	values := args
+	for _, field := range entry.Fields {
+		if field.Key == "stacktrace" {
+			return
+		}
+		values = append(values[:len(values):len(values)], field.Value)
+	}
+
+	for _, value := range values {
+		if err, ok := value.(error); ok {
+			if stack, ok := errorStack(err); ok {
+				entry.Fields = append(entry.Fields, Field{Key: "stacktrace", Value: stack})
+				return
+			}
+		}
+	}
+}
+
+// stackSuffix renders the entry's "stacktrace" field for text output,
+// indented below the message.
+func (entry *Entry) stackSuffix() string {
+	for _, field := range entry.Fields {
+		if stack, ok := field.Value.(string); ok && field.Key == "stacktrace" {
+			return "\n\t" + strings.ReplaceAll(stack, "\n", "\n\t")
+		}
+	}
+
+	return ""
+}
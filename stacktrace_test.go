@@ -0,0 +1,54 @@
+package golog_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+// tracedError mimics the errors of github.com/pkg/errors.
+type tracedError struct {
+	msg string
+}
+
+type fakeStackTrace []string
+
+func (e *tracedError) Error() string {
+	return e.msg
+}
+
+func (e *tracedError) StackTrace() fakeStackTrace {
+	return fakeStackTrace{"main.load\n\t/src/main.go:12", "main.main\n\t/src/main.go:5"}
+}
+
+func (st fakeStackTrace) Format(s fmt.State, verb rune) {
+	for _, frame := range st {
+		fmt.Fprintf(s, "\n%s", frame)
+	}
+}
+
+func TestErrorStackTrace(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	err := fmt.Errorf("loading config: %w", &tracedError{msg: "file not found"})
+	golog.Error("startup failed: %v", err)
+	golog.Error("plain: %v", fmt.Errorf("no stack"))
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+
+	fields := sink.entries[0].Fields
+	want := "main.load\n\t/src/main.go:12\nmain.main\n\t/src/main.go:5"
+	if len(fields) != 1 || fields[0].Key != "stacktrace" || fields[0].Value != want {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+	if len(sink.entries[1].Fields) != 0 {
+		t.Errorf("unexpected fields %+v", sink.entries[1].Fields)
+	}
+}
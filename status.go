@@ -0,0 +1,62 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+)
+
+type statusLine struct {
+	rows int
+}
+
+// SetStatus pins a status line, e.g. the current phase and progress counts,
+// to the bottom row of the terminal while entries keep scrolling above it.
+// The rows above are made a scroll region and the status is drawn with the
+// cursor saved and restored around it. It does nothing unless the logger
+// writes to a terminal; call ClearStatus before the program exits.
+func (gl *GoLog) SetStatus(text string, args ...interface{}) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	file, ok := gl.out.(*os.File)
+	if !ok || !isatty.IsTerminal(file.Fd()) {
+		return
+	}
+
+	cols, rows, err := term.GetSize(int(file.Fd()))
+	if err != nil || rows < 2 {
+		return
+	}
+
+	if gl.status == nil || gl.status.rows != rows {
+		// Scroll everything up a line so the cursor is inside the region
+		// once it is set, then confine scrolling to the rows above the
+		// status. Setting the region homes the cursor, hence the save.
+		fmt.Fprintf(file, "\n\x1b[1A\x1b7\x1b[1;%dr\x1b8", rows-1)
+		gl.status = &statusLine{rows: rows}
+	}
+
+	status := sprintf(text, args)
+	if utf8.RuneCountInString(status) > cols {
+		status = string([]rune(status)[:cols])
+	}
+
+	fmt.Fprintf(file, "\x1b7\x1b[%d;1H\x1b[2K%s\x1b8", rows, status)
+}
+
+// ClearStatus removes the status line and restores normal scrolling.
+func (gl *GoLog) ClearStatus() {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	if gl.status == nil {
+		return
+	}
+
+	fmt.Fprintf(gl.out, "\x1b7\x1b[r\x1b[%d;1H\x1b[2K\x1b8", gl.status.rows)
+	gl.status = nil
+}
@@ -0,0 +1,32 @@
+package golog_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestStatusWithoutTerminal(t *testing.T) {
+	if os.Getenv("GOLOG_STATUS_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetStatus("phase %d/%d", 1, 3)
+		golog.Info("working")
+		golog.Std.ClearStatus()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestStatusWithoutTerminal$")
+	cmd.Env = append(os.Environ(), "GOLOG_STATUS_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(out), "\x1b") || strings.Contains(string(out), "phase") {
+		t.Errorf("status leaked into non-terminal output: %q", out)
+	}
+}
@@ -0,0 +1,60 @@
+package golog
+
+import (
+	"log"
+	"runtime"
+	"strings"
+)
+
+// StdLogger returns a standard library logger that logs at level, for APIs
+// such as http.Server.ErrorLog that only accept a *log.Logger. Each print
+// becomes one entry, attributed to the code that called the *log.Logger.
+func (gl *GoLog) StdLogger(level Level) *log.Logger {
+	return log.New(&stdWriter{gl: gl, level: level}, "", 0)
+}
+
+type stdWriter struct {
+	gl    *GoLog
+	level Level
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	w.gl.logFrom(callerOutside("log."), w.level, strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// logFrom logs message as is from the call site frame.
+func (gl *GoLog) logFrom(frame runtime.Frame, level Level, message string) {
+	entry := gl.newEntryAt(0, level, "%s", []interface{}{message})
+	setCallerFrame(entry, frame)
+	gl.config().formatCaller(entry)
+	gl.write(entry)
+}
+
+// callerOutside returns the innermost frame of the calling goroutine that
+// belongs neither to golog nor to a function with one of the prefixes.
+func callerOutside(prefixes ...string) runtime.Frame {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	it := runtime.CallersFrames(pcs)
+	for {
+		frame, more := it.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) && !hasAnyPrefix(frame.Function, prefixes) {
+			return frame
+		}
+		if !more {
+			return runtime.Frame{}
+		}
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package golog_test
+
+import (
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestStdLogger(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	logger := golog.Std.StdLogger(golog.LWarning)
+	logger.Printf("http: TLS handshake error from %s", "10.0.0.1")
+	logger.Println("two\nlines")
+	golog.Std.StdLogger(golog.LDebug).Print("dropped")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+	if entry := sink.entries[0]; entry.Level != golog.LWarning || entry.Message != "http: TLS handshake error from 10.0.0.1" || entry.Caller != "stdlog_test.go:17" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry := sink.entries[1]; entry.Message != "two\nlines" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,120 @@
+package golog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// textHeader matches the default header template; the level is either a
+// padded label or an icon.
+var textHeader = regexp.MustCompile(`^\[([^\]]+)\] (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d{1,9})?) (?:\[([^\]]+)\] )?(?:(\S+) )?\(([^)]*)\)(?: #(\d+))?(?: \[([^\]]+)\])?: `)
+
+// TextReader parses lines written with the default header template back
+// into entries, so plain-text logs can be filtered or re-encoded. Colors
+// and hyperlinks are stripped. Lines that do not start with a header
+// continue the previous entry's message; tab-indented lines are its
+// "stacktrace" field. Fields written as key=value pairs stay part of the
+// message. Dates are read in the local time zone, keeping any fractional
+// seconds they were written with.
+type TextReader struct {
+	scanner *bufio.Scanner
+	line    int
+	pending *Entry
+	indent  string
+	stack   []string
+}
+
+func NewTextReader(r io.Reader) *TextReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	return &TextReader{scanner: scanner}
+}
+
+// Next returns the next entry or io.EOF at the end of the input.
+func (tr *TextReader) Next() (*Entry, error) {
+	for tr.scanner.Scan() {
+		tr.line++
+		line := escapeSequence.ReplaceAllString(tr.scanner.Text(), "")
+
+		m := textHeader.FindStringSubmatch(line)
+		if m == nil {
+			if tr.pending == nil {
+				return nil, fmt.Errorf("golog: line %d is not a log entry", tr.line)
+			}
+
+			if strings.HasPrefix(line, "\t") {
+				tr.stack = append(tr.stack, line[1:])
+			} else {
+				tr.pending.Message += "\n" + strings.TrimPrefix(line, tr.indent)
+			}
+			continue
+		}
+
+		level, ok := parseLevelLabel(m[1])
+		if !ok {
+			return nil, fmt.Errorf("golog: line %d has unknown level %q", tr.line, m[1])
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", m[2], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("golog: line %d: %v", tr.line, err)
+		}
+
+		entry := tr.finish()
+		tr.pending = &Entry{
+			Level:   level,
+			Time:    t,
+			Prefix:  m[3],
+			Logger:  m[4],
+			Caller:  m[5],
+			ID:      m[7],
+			Message: line[len(m[0]):],
+		}
+		tr.pending.Goroutine, _ = strconv.ParseUint(m[6], 10, 64)
+		tr.indent = strings.Repeat(" ", utf8.RuneCountInString(m[0]))
+		if entry != nil {
+			return entry, nil
+		}
+	}
+
+	if err := tr.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if entry := tr.finish(); entry != nil {
+		return entry, nil
+	}
+
+	return nil, io.EOF
+}
+
+// finish completes and hands back the entry being assembled.
+func (tr *TextReader) finish() *Entry {
+	entry := tr.pending
+	if entry != nil && len(tr.stack) > 0 {
+		entry.Fields = append(entry.Fields, Field{Key: "stacktrace", Value: strings.Join(tr.stack, "\n")})
+	}
+	tr.pending = nil
+	tr.stack = nil
+
+	return entry
+}
+
+// parseLevelLabel maps a level label, padded or not and in any case, or a
+// built-in icon back to its level.
+func parseLevelLabel(label string) (Level, bool) {
+	label = strings.TrimSpace(label)
+	for _, level := range allLevels() {
+		if label == EmojiIcons[level] || label == NerdFontIcons[level] {
+			return level, true
+		}
+	}
+	level, err := ParseLevel(label)
+
+	return level, err == nil
+}
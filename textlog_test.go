@@ -0,0 +1,57 @@
+package golog_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestTextReader(t *testing.T) {
+	input := strings.Join([]string{
+		"[  info] 2018-07-01 12:00:00.250 (main.go:10): started",
+		"[\x1b[33m  warn\x1b[0m] 2018-07-01 12:00:01 (\x1b[36mmain.go:11\x1b[0m) [01H2X]: low disk",
+		"second line",
+		"[❌] 2018-07-01 12:00:02 db.pool (db.go:3): query failed",
+		"\t/src/db.go:3",
+		"\t/src/main.go:12",
+	}, "\n")
+
+	tr := golog.NewTextReader(strings.NewReader(input))
+	var entries []*golog.Entry
+	for {
+		entry, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if e := entries[0]; e.Level != golog.LInfo || e.Caller != "main.go:10" || e.Message != "started" || e.Time.Nanosecond() != 250000000 {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e := entries[1]; e.Level != golog.LWarning || e.Caller != "main.go:11" || e.ID != "01H2X" || e.Message != "low disk\nsecond line" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	e := entries[2]
+	if e.Level != golog.LError || e.Logger != "db.pool" || e.Caller != "db.go:3" || e.Message != "query failed" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if len(e.Fields) != 1 || e.Fields[0].Key != "stacktrace" || e.Fields[0].Value != "/src/db.go:3\n/src/main.go:12" {
+		t.Errorf("unexpected fields %+v", e.Fields)
+	}
+}
+
+func TestTextReaderRejectsOtherText(t *testing.T) {
+	tr := golog.NewTextReader(strings.NewReader("not a log line\n"))
+	if _, err := tr.Next(); err == nil || err == io.EOF {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package golog
+
+import "github.com/fatih/color"
+
+// Theme maps levels to their console color. Levels missing from a theme
+// keep their default color.
+type Theme map[Level]*color.Color
+
+// DefaultTheme holds the colors used unless SetTheme or SetLevelColor
+// change them.
+var DefaultTheme = Theme{
+	LTrace:   color.New(color.FgWhite),
+	LDebug:   color.New(color.FgBlue),
+	LInfo:    color.New(color.FgGreen),
+	LNotice:  color.New(color.FgMagenta),
+	LWarning: color.New(color.FgYellow),
+	LError:   color.New(color.FgRed),
+	LDPanic:  color.New(color.FgHiRed, color.Bold),
+	LPanic:   color.New(color.FgHiWhite, color.BgRed),
+	LFatal:   color.New(color.FgHiWhite, color.BgRed, color.Bold),
+}
+
+// DarkTheme uses bright colors that stay readable on dark backgrounds,
+// where the default blue of LDebug is hard to read.
+var DarkTheme = Theme{
+	LTrace:   color.New(color.FgHiBlack),
+	LDebug:   color.New(color.FgHiCyan),
+	LInfo:    color.New(color.FgHiGreen),
+	LNotice:  color.New(color.FgHiMagenta),
+	LWarning: color.New(color.FgHiYellow),
+	LError:   color.New(color.FgHiRed),
+	LDPanic:  color.New(color.FgHiRed, color.Bold),
+	LPanic:   color.New(color.FgHiWhite, color.BgRed),
+	LFatal:   color.New(color.FgHiWhite, color.BgRed, color.Bold),
+}
+
+// LightTheme avoids white and yellow, which vanish on light backgrounds.
+var LightTheme = Theme{
+	LTrace:   color.New(color.FgHiBlack),
+	LDebug:   color.New(color.FgBlue),
+	LInfo:    color.New(color.FgGreen),
+	LNotice:  color.New(color.FgMagenta),
+	LWarning: color.New(color.FgRed),
+	LError:   color.New(color.FgRed, color.Bold),
+	LDPanic:  color.New(color.FgHiWhite, color.BgMagenta, color.Bold),
+	LPanic:   color.New(color.FgHiWhite, color.BgRed),
+	LFatal:   color.New(color.FgHiWhite, color.BgRed, color.Bold),
+}
+
+// SolarizedTheme suits terminals with the Solarized palette
+// (https://ethanschoonover.com/solarized), which maps the bright ANSI
+// colors to its base tones and orange and violet.
+var SolarizedTheme = Theme{
+	LTrace:   color.New(color.FgHiGreen),   // base01
+	LDebug:   color.New(color.FgCyan),      // cyan
+	LInfo:    color.New(color.FgGreen),     // green
+	LNotice:  color.New(color.FgHiMagenta), // violet
+	LWarning: color.New(color.FgYellow),    // yellow
+	LError:   color.New(color.FgHiRed),     // orange
+	LDPanic:  color.New(color.FgRed, color.Bold),
+	LPanic:   color.New(color.FgHiWhite, color.BgRed),
+	LFatal:   color.New(color.FgHiWhite, color.BgRed, color.Bold),
+}
+
+// SetTheme changes the console colors of the levels, e.g. to DarkTheme.
+// nil restores DefaultTheme. Colors of levels added with RegisterLevel
+// apply unless the theme has the level.
+func (gl *GoLog) SetTheme(theme Theme) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.levelColors = theme
+	gl.publish()
+}
+
+// SetLevelColor changes the console color of level on top of the current
+// theme, e.g.
+//
+//	golog.Std.SetLevelColor(golog.LDebug, color.New(color.FgHiCyan))
+//
+// nil restores the default color of level.
+func (gl *GoLog) SetLevelColor(level Level, c *color.Color) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	colors := make(Theme, len(gl.levelColors)+1)
+	for l, lc := range gl.levelColors {
+		colors[l] = lc
+	}
+	if c != nil {
+		colors[level] = c
+	} else {
+		delete(colors, level)
+	}
+
+	gl.levelColors = colors
+	gl.publish()
+}
+
+// color is the console color of level.
+func (style lineStyle) color(level Level) *color.Color {
+	if c := style.colors[level]; c != nil {
+		return c
+	}
+
+	return levelColor(level)
+}
@@ -0,0 +1,51 @@
+package golog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/miyaizu/golog"
+)
+
+func TestTheme(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug})
+	golog.SetOutput(golog.OStdout)
+
+	var console bytes.Buffer
+	sink, err := golog.NewWriterSink(&golog.WriterSinkOption{Writer: &console, Colorize: true, Theme: golog.DarkTheme})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golog.Std.AddSink(sink)
+
+	golog.Debug("dark")
+	golog.Std.Close()
+
+	if line := console.String(); !strings.Contains(line, "\x1b[96m debug\x1b[0m") {
+		t.Errorf("unexpected line %q", line)
+	}
+}
+
+func TestSetLevelColor(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LDebug, Colorize: true, Theme: golog.SolarizedTheme})
+	golog.SetOutput(golog.OStdout)
+
+	var out bytes.Buffer
+	golog.Std.SetLevelsOutput(&out, golog.LDebug, golog.LInfo, golog.LWarning)
+	golog.Std.SetForceColor(true)
+	golog.Std.SetLevelColor(golog.LDebug, color.New(color.FgHiCyan))
+	golog.Std.SetLevelColor(golog.LInfo, nil)
+
+	golog.Debug("debug")
+	golog.Info("info")
+	golog.Warn("warn")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	for i, want := range []string{"[\x1b[96m debug\x1b[0m]", "[\x1b[32m  info\x1b[0m]", "[\x1b[33m  warn\x1b[0m]"} {
+		if i >= len(lines) || !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d: want prefix %q in %q", i, want, out.String())
+		}
+	}
+}
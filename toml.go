@@ -0,0 +1,295 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeTOML decodes the subset of TOML that logging config files use into
+// v, through its json tags: [tables], [[arrays of tables]], bare and quoted
+// keys, dotted keys, strings, integers, floats, booleans and arrays of
+// those. Dates and inline tables are not supported.
+func decodeTOML(data []byte, v interface{}) error {
+	root := map[string]interface{}{}
+	table := root
+
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if line == "" {
+			continue
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "[["):
+			if !strings.HasSuffix(line, "]]") {
+				err = fmt.Errorf("unterminated table header")
+				break
+			}
+			table, err = tomlArrayTable(root, line[2:len(line)-2])
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				err = fmt.Errorf("unterminated table header")
+				break
+			}
+			table, err = tomlTable(root, line[1:len(line)-1])
+		default:
+			err = tomlKeyValue(table, line)
+		}
+		if err != nil {
+			return fmt.Errorf("toml line %d: %v", n+1, err)
+		}
+	}
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// stripTOMLComment removes a # comment that is not inside a string.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// tomlKeys splits a possibly dotted, possibly quoted key.
+func tomlKeys(s string) ([]string, error) {
+	var keys []string
+	for s = strings.TrimSpace(s); ; {
+		var key string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			v, rest, err := tomlString(s)
+			if err != nil {
+				return nil, err
+			}
+			key, s = v, strings.TrimSpace(rest)
+		} else {
+			i := strings.IndexByte(s, '.')
+			if i < 0 {
+				i = len(s)
+			}
+			key, s = strings.TrimSpace(s[:i]), s[i:]
+			if key == "" || strings.ContainsAny(key, " \t\"'") {
+				return nil, fmt.Errorf("invalid key %q", key)
+			}
+		}
+		keys = append(keys, key)
+
+		if s == "" {
+			return keys, nil
+		}
+		if s[0] != '.' {
+			return nil, fmt.Errorf("unexpected %q after key", s)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+}
+
+// tomlDescend returns the table at keys below table, creating tables as
+// needed; for an array of tables it descends into the last one.
+func tomlDescend(table map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, key := range keys {
+		switch next := table[key].(type) {
+		case nil:
+			t := map[string]interface{}{}
+			table[key] = t
+			table = t
+		case map[string]interface{}:
+			table = next
+		case []interface{}:
+			t, ok := next[len(next)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%q is not a table", key)
+			}
+			table = t
+		default:
+			return nil, fmt.Errorf("%q is not a table", key)
+		}
+	}
+
+	return table, nil
+}
+
+func tomlTable(root map[string]interface{}, header string) (map[string]interface{}, error) {
+	keys, err := tomlKeys(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return tomlDescend(root, keys)
+}
+
+func tomlArrayTable(root map[string]interface{}, header string) (map[string]interface{}, error) {
+	keys, err := tomlKeys(header)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := tomlDescend(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	key := keys[len(keys)-1]
+	t := map[string]interface{}{}
+	switch array := parent[key].(type) {
+	case nil:
+		parent[key] = []interface{}{t}
+	case []interface{}:
+		parent[key] = append(array, t)
+	default:
+		return nil, fmt.Errorf("%q is not an array of tables", key)
+	}
+
+	return t, nil
+}
+
+func tomlKeyValue(table map[string]interface{}, line string) error {
+	i := strings.IndexByte(line, '=')
+	if i < 0 {
+		return fmt.Errorf("expected key = value")
+	}
+	keys, err := tomlKeys(line[:i])
+	if err != nil {
+		return err
+	}
+	table, err = tomlDescend(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	value, rest, err := tomlValue(strings.TrimSpace(line[i+1:]))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rest) != "" {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+
+	key := keys[len(keys)-1]
+	if _, ok := table[key]; ok {
+		return fmt.Errorf("duplicate key %q", key)
+	}
+	table[key] = value
+
+	return nil
+}
+
+// tomlValue parses the value at the start of s and returns the rest.
+func tomlValue(s string) (interface{}, string, error) {
+	if s == "" {
+		return nil, "", fmt.Errorf("missing value")
+	}
+
+	switch s[0] {
+	case '"', '\'':
+		return tomlString(s)
+	case '[':
+		var array []interface{}
+		s = strings.TrimSpace(s[1:])
+		for {
+			if s != "" && s[0] == ']' {
+				return array, s[1:], nil
+			}
+			v, rest, err := tomlValue(s)
+			if err != nil {
+				return nil, "", err
+			}
+			array = append(array, v)
+
+			s = strings.TrimSpace(rest)
+			if s != "" && s[0] == ',' {
+				s = strings.TrimSpace(s[1:])
+			} else if s == "" || s[0] != ']' {
+				return nil, "", fmt.Errorf("unterminated array")
+			}
+		}
+	}
+
+	end := strings.IndexAny(s, ",]")
+	if end < 0 {
+		end = len(s)
+	}
+	token, rest := strings.TrimSpace(s[:end]), s[end:]
+	switch token {
+	case "true":
+		return true, rest, nil
+	case "false":
+		return false, rest, nil
+	}
+	number := strings.ReplaceAll(token, "_", "")
+	if n, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return n, rest, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, rest, nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported value %q", token)
+}
+
+// tomlString parses a basic "string" or a literal 'string' at the start of
+// s and returns the rest.
+func tomlString(s string) (string, string, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), s[i+1:], nil
+		case c == '\\' && quote == '"' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(s[i])
+			case 'u', 'U':
+				size := 4
+				if s[i] == 'U' {
+					size = 8
+				}
+				if i+size >= len(s) {
+					return "", "", fmt.Errorf("invalid escape in %s", s)
+				}
+				r, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+				if err != nil {
+					return "", "", fmt.Errorf("invalid escape in %s", s)
+				}
+				b.WriteRune(rune(r))
+				i += size
+			default:
+				return "", "", fmt.Errorf("invalid escape \\%c", s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string %s", s)
+}
@@ -0,0 +1,65 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var watchdogMu sync.Mutex
+var watchdogDeadline time.Duration
+var watchdogOut io.Writer = os.Stderr
+
+// SetWatchdog reports to fallback (os.Stderr if nil) every flush of a
+// batching sink or of the async queue, and every entry written by the async
+// worker, that has not completed within deadline, and again once it does
+// complete, so a hung backend or output does not stall logging silently. A
+// zero deadline disables the watchdog.
+func SetWatchdog(deadline time.Duration, fallback io.Writer) {
+	if fallback == nil {
+		fallback = os.Stderr
+	}
+
+	watchdogMu.Lock()
+	defer watchdogMu.Unlock()
+
+	watchdogDeadline = deadline
+	watchdogOut = fallback
+}
+
+// watchFlush runs flush under the watchdog.
+func watchFlush(name string, entries int, flush func() error) error {
+	watchdogMu.Lock()
+	deadline := watchdogDeadline
+	out := watchdogOut
+	watchdogMu.Unlock()
+
+	if deadline <= 0 {
+		return flush()
+	}
+
+	start := time.Now()
+	var stalled sync.Mutex
+	var fired bool
+	timer := time.AfterFunc(deadline, func() {
+		stalled.Lock()
+		defer stalled.Unlock()
+
+		fired = true
+		fmt.Fprintf(out, "golog: %s flush of %d entries has not completed after %s\n", name, entries, deadline)
+	})
+
+	err := flush()
+
+	if !timer.Stop() {
+		stalled.Lock()
+		if fired {
+			fmt.Fprintf(out, "golog: %s flush of %d entries completed after %s\n", name, entries, time.Since(start))
+		}
+		stalled.Unlock()
+	}
+
+	return err
+}
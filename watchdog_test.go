@@ -0,0 +1,95 @@
+package golog_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miyaizu/golog"
+)
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestWatchdog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	fallback := &lockedBuffer{}
+	golog.SetWatchdog(20*time.Millisecond, fallback)
+	defer golog.SetWatchdog(0, nil)
+
+	sink, err := golog.NewHoneycombSink(&golog.HoneycombSinkOption{
+		APIKey:   "key",
+		Dataset:  "api-logs",
+		Endpoint: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(&golog.Entry{Level: golog.LInfo, Time: time.Now(), Message: "slow"})
+	if err := sink.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := fallback.String()
+	if !strings.Contains(out, "golog: honeycomb sink flush of 1 entries has not completed after 20ms") ||
+		!strings.Contains(out, "golog: honeycomb sink flush of 1 entries completed after") {
+		t.Errorf("unexpected watchdog output %q", out)
+	}
+}
+
+type slowSink struct{ delay time.Duration }
+
+func (s slowSink) Write(entry *golog.Entry) error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+func (s slowSink) Close() error { return nil }
+
+func TestWatchdogAsync(t *testing.T) {
+	fallback := &lockedBuffer{}
+	golog.SetWatchdog(20*time.Millisecond, fallback)
+	defer golog.SetWatchdog(0, nil)
+
+	gl := golog.NewGoLog(golog.OStdout, &golog.GoLogOption{MinLevel: golog.LInfo})
+	gl.SetLevelOutput(golog.LError, &bytes.Buffer{})
+	gl.AddSink(slowSink{100 * time.Millisecond})
+	gl.SetAsync(&golog.AsyncOption{})
+	defer gl.SetAsync(nil)
+
+	gl.Error("failed")
+	gl.Flush()
+
+	out := fallback.String()
+	if !strings.Contains(out, "golog: async writer flush of 1 entries has not completed after 20ms") ||
+		!strings.Contains(out, "golog: async queue flush of") {
+		t.Errorf("unexpected watchdog output %q", out)
+	}
+}
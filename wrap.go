@@ -0,0 +1,93 @@
+package golog
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// WrapTerminal wraps at the width of the terminal the logger writes to,
+// or of $COLUMNS, re-detected for every entry so resized panes follow.
+const WrapTerminal = -1
+
+const minWrapWidth = 20
+
+var escapeSequence = regexp.MustCompile("\x1b\\[[0-9;]*m|\x1b]8;;[^\x1b]*\x1b\\\\")
+
+// SetWrap wraps console messages longer than width columns, indenting the
+// continuation lines under the start of the message so headers stay
+// aligned. width is a column count or WrapTerminal; 0 disables wrapping.
+func (gl *GoLog) SetWrap(width int) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.wrap = width
+	gl.publish()
+}
+
+func (c *config) wrapWidth(out io.Writer) int {
+	if c.wrap != WrapTerminal {
+		return c.wrap
+	}
+
+	if file, ok := out.(*os.File); ok {
+		if width, _, err := term.GetSize(int(file.Fd())); err == nil {
+			return width
+		}
+	}
+
+	width, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return width
+}
+
+// wrapMessage word-wraps message for a line of width columns that starts
+// with header, indenting continuation lines by the header's visible width.
+// Words longer than a line are broken.
+func wrapMessage(header, message string, width int) string {
+	indent := utf8.RuneCountInString(escapeSequence.ReplaceAllString(header, ""))
+	room := width - indent
+	if width <= 0 || room < minWrapWidth {
+		return message
+	}
+
+	pad := strings.Repeat(" ", indent)
+	var b strings.Builder
+	for i, line := range strings.Split(message, "\n") {
+		if i > 0 {
+			b.WriteString("\n" + pad)
+		}
+
+		col := 0
+		for j, word := range strings.Split(line, " ") {
+			n := utf8.RuneCountInString(word)
+			if j > 0 {
+				if col+1+n <= room {
+					b.WriteByte(' ')
+					col++
+				} else {
+					b.WriteString("\n" + pad)
+					col = 0
+				}
+			}
+
+			for n > room-col {
+				runes := []rune(word)
+				b.WriteString(string(runes[:room-col]))
+				b.WriteString("\n" + pad)
+				word = string(runes[room-col:])
+				n -= room - col
+				col = 0
+			}
+
+			b.WriteString(word)
+			col += n
+		}
+	}
+
+	return b.String()
+}
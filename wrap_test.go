@@ -0,0 +1,44 @@
+package golog_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWrap(t *testing.T) {
+	if os.Getenv("GOLOG_WRAP_TEST") == "1" {
+		golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+		golog.SetOutput(golog.OStdout)
+		golog.Std.SetWrap(80)
+		golog.Info("the quick brown fox jumps over the lazy dog and keeps running far away")
+		golog.Info("abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz\nshort")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestWrap$")
+	cmd.Env = append(os.Environ(), "GOLOG_WRAP_TEST=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(out), "\n")
+	indent := strings.Repeat(" ", strings.Index(lines[0], "): ")+3)
+	want := []string{
+		"the quick brown fox jumps over",
+		indent + "the lazy dog and keeps running",
+		indent + "far away",
+		"abcdefghijklmnopqrstuvwxyzabcdef",
+		indent + "ghijklmnopqrstuvwxyz",
+		indent + "short",
+	}
+	for i, suffix := range want {
+		if !strings.HasSuffix(lines[i], suffix) || len(lines[i]) > 80 {
+			t.Errorf("line %d: got %q, want suffix %q", i, lines[i], suffix)
+		}
+	}
+}
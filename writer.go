@@ -0,0 +1,34 @@
+package golog
+
+import (
+	"bytes"
+	"io"
+)
+
+// WriterLevel returns a writer that logs every line written to it at
+// level, e.g. to capture a subprocess's stderr:
+//
+//	cmd.Stderr = golog.Std.WriterLevel(golog.LWarning)
+//
+// Each Write is split on newlines and empty lines are skipped. A line
+// split across two writes becomes two entries.
+func (gl *GoLog) WriterLevel(level Level) io.Writer {
+	return &levelWriter{gl: gl, level: level}
+}
+
+type levelWriter struct {
+	gl    *GoLog
+	level Level
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	frame := callerOutside("io.", "bufio.", "os/exec.", "fmt.")
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) > 0 {
+			w.gl.logFrom(frame, w.level, string(line))
+		}
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,27 @@
+package golog_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/miyaizu/golog"
+)
+
+func TestWriterLevel(t *testing.T) {
+	golog.SetupLogger(&golog.GoLogOption{MinLevel: golog.LInfo})
+	golog.SetOutput(golog.OStdout)
+
+	sink := &memorySink{}
+	golog.Std.AddSink(sink)
+
+	w := golog.Std.WriterLevel(golog.LWarning)
+	fmt.Fprintf(w, "first\r\n\nsecond\n")
+
+	messages := sink.messages()
+	if len(messages) != 2 || messages[0] != "first" || messages[1] != "second" {
+		t.Fatalf("unexpected messages %q", messages)
+	}
+	if entry := sink.entries[0]; entry.Level != golog.LWarning || entry.Caller != "writer_test.go:18" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}